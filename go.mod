@@ -11,6 +11,7 @@ require (
 	k8s.io/cli-runtime v0.19.0
 	k8s.io/client-go v0.19.0
 	k8s.io/kubectl v0.19.0
+	sigs.k8s.io/yaml v1.2.0
 )
 
 require (
@@ -78,5 +79,4 @@ require (
 	k8s.io/utils v0.0.0-20200729134348-d5654de09c73 // indirect
 	sigs.k8s.io/kustomize v2.0.3+incompatible // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.0.1 // indirect
-	sigs.k8s.io/yaml v1.2.0 // indirect
 )
@@ -0,0 +1,100 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// ClusterScopedDir is the directory name cluster-scoped objects are written to instead of their namespace.
+const ClusterScopedDir = "_cluster"
+
+// strippedMetadataFields is the list of metadata fields populated by the server.
+var strippedMetadataFields = []string{
+	"managedFields",
+	"resourceVersion",
+	"uid",
+	"selfLink",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"deletionGracePeriodSeconds",
+	// Owners are identified by their UIDs, which are gone with the owners, and the garbage collector
+	// would delete restored objects which refer to them.
+	"ownerReferences",
+}
+
+// strippedAnnotations is the list of annotations which refer to the UIDs of other objects.
+var strippedAnnotations = []string{
+	// The token controller would delete restored token Secrets which refer to the UID of the old ServiceAccount.
+	// Without the annotation, it binds them to the ServiceAccount of the same name again.
+	"kubernetes.io/service-account.uid",
+}
+
+// Write writes the manifest of obj to dir/<namespace>/<kind>/<name>.yaml and returns its path.
+// The manifest is stripped of status and the metadata populated by the server so that it can be applied again.
+func Write(dir string, obj runtime.Object) (string, error) {
+	u, err := Strip(obj)
+	if err != nil {
+		return "", err
+	}
+
+	namespace := u.GetNamespace()
+	if namespace == "" {
+		namespace = ClusterScopedDir
+	}
+
+	kindDir := filepath.Join(dir, namespace, strings.ToLower(u.GetKind()))
+	if err := os.MkdirAll(kindDir, 0o755); err != nil {
+		return "", err
+	}
+
+	b, err := yaml.Marshal(u.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s/%s: %w", u.GetKind(), u.GetName(), err)
+	}
+
+	path := filepath.Join(kindDir, u.GetName()+".yaml")
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// Strip returns a copy of obj without status and the metadata populated by the server or referring to the UIDs of others.
+func Strip(obj runtime.Object) (*unstructured.Unstructured, error) {
+	var u *unstructured.Unstructured
+
+	if uobj, ok := obj.(*unstructured.Unstructured); ok {
+		u = uobj.DeepCopy()
+	} else {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		u = &unstructured.Unstructured{Object: m}
+	}
+
+	unstructured.RemoveNestedField(u.Object, "status")
+	for _, field := range strippedMetadataFields {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+
+	if annotations := u.GetAnnotations(); annotations != nil {
+		for _, key := range strippedAnnotations {
+			delete(annotations, key)
+		}
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		u.SetAnnotations(annotations)
+	}
+
+	return u, nil
+}
@@ -0,0 +1,140 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestWrite(t *testing.T) {
+	fakeTime := metav1.Unix(0, 0)
+
+	tests := []struct {
+		name     string
+		obj      runtime.Object
+		wantPath string
+		want     string
+	}{
+		{
+			name: "namespaced object should be written without server-populated fields",
+			obj: &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "fake-cm",
+					Namespace:         "fake-ns",
+					UID:               "fake-uid",
+					ResourceVersion:   "1",
+					CreationTimestamp: fakeTime,
+					Labels:            map[string]string{"app": "fake"},
+					ManagedFields: []metav1.ManagedFieldsEntry{
+						{Manager: "kubectl"},
+					},
+				},
+				Data: map[string]string{"key": "value"},
+			},
+			wantPath: filepath.Join("fake-ns", "configmap", "fake-cm.yaml"),
+			want: `apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  labels:
+    app: fake
+  name: fake-cm
+  namespace: fake-ns
+`,
+		},
+		{
+			name: "object should be written without references to the UIDs of others",
+			obj: &corev1.Secret{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "Secret",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-token",
+					Namespace: "fake-ns",
+					Annotations: map[string]string{
+						corev1.ServiceAccountNameKey: "fake-sa",
+						corev1.ServiceAccountUIDKey:  "fake-sa-uid",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{APIVersion: "v1", Kind: "ServiceAccount", Name: "fake-sa", UID: "fake-sa-uid"},
+					},
+				},
+				Type: corev1.SecretTypeServiceAccountToken,
+			},
+			wantPath: filepath.Join("fake-ns", "secret", "fake-token.yaml"),
+			want: `apiVersion: v1
+kind: Secret
+metadata:
+  annotations:
+    kubernetes.io/service-account.name: fake-sa
+  name: fake-token
+  namespace: fake-ns
+type: kubernetes.io/service-account-token
+`,
+		},
+		{
+			name: "cluster-scoped object should be written without status",
+			obj: &corev1.PersistentVolume{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "PersistentVolume",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake-pv",
+				},
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+				},
+				Status: corev1.PersistentVolumeStatus{
+					Phase: corev1.VolumeReleased,
+				},
+			},
+			wantPath: filepath.Join(ClusterScopedDir, "persistentvolume", "fake-pv.yaml"),
+			want: `apiVersion: v1
+kind: PersistentVolume
+metadata:
+  name: fake-pv
+spec:
+  persistentVolumeReclaimPolicy: Retain
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			path, err := Write(dir, tt.obj)
+			if err != nil {
+				t.Errorf("Write() error = %v", err)
+				return
+			}
+			if diff := cmp.Diff(filepath.Join(dir, tt.wantPath), path); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Errorf("failed to read backup: %v", err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, string(b)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
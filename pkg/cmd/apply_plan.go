@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/kubernetes/scheme"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/micnncim/kubectl-reap/pkg/plan"
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+const (
+	applyPlanShortDescription = `
+Delete resources in a plan written by --plan-file. Resources which have changed or been deleted since planning
+are not deleted, and make the command exit with a non-zero status. Resources which have been protected since planning,
+or are in namespaces not allowed by the namespace flags, are not deleted either.
+`
+
+	applyPlanExample = `
+  # Delete resources in the plan
+  $ kubectl reap apply-plan plan.yaml
+
+  # Delete resources in the plan as server-side dry-run
+  $ kubectl reap apply-plan plan.yaml --dry-run=server
+
+  # Back up resources in the plan to the directory before deleting them
+  $ kubectl reap apply-plan plan.yaml --backup-dir=./backup
+
+  # Delete resources in the plan immediately, and wait for them to be gone
+  $ kubectl reap apply-plan plan.yaml --grace-period=1 --wait`
+)
+
+// applyResult is the result of applying an entry of a plan.
+type applyResult int
+
+const (
+	applyResultFailed applyResult = iota
+	applyResultDeleted
+	applyResultChanged // changed or deleted since planning
+	applyResultProtected
+)
+
+type applyPlanRunner struct {
+	configFlags *genericclioptions.ConfigFlags
+	printFlags  *genericclioptions.PrintFlags
+
+	includeNamespaces []string
+	excludeNamespaces []string
+	namespaceSelector string
+
+	dryRunStrategy cmdutil.DryRunStrategy
+
+	deleter deleter
+
+	clientForMapping func(*apimeta.RESTMapping) (cliresource.RESTClient, error)
+	mapper           apimeta.RESTMapper
+	namespaceFilter  namespaceFilter
+	protection       *protection
+	printer          printers.ResourcePrinter
+	protectedPrinter printers.ResourcePrinter
+
+	genericclioptions.IOStreams
+}
+
+func newApplyPlanRunner(ioStreams genericclioptions.IOStreams) *applyPlanRunner {
+	return &applyPlanRunner{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		printFlags:  genericclioptions.NewPrintFlags(printedOperationTypeDeleted).WithTypeSetter(scheme.Scheme),
+		IOStreams:   ioStreams,
+	}
+}
+
+func NewCmdApplyPlan(streams genericclioptions.IOStreams) *cobra.Command {
+	r := newApplyPlanRunner(streams)
+
+	cmd := &cobra.Command{
+		Use:     "apply-plan PLAN",
+		Short:   applyPlanShortDescription,
+		Example: applyPlanExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			f := cmdutil.NewFactory(r.configFlags)
+
+			cmdutil.CheckErr(r.Validate())
+			cmdutil.CheckErr(r.Complete(f, cmd))
+			cmdutil.CheckErr(r.Run(context.Background(), args[0]))
+		},
+	}
+
+	r.configFlags.AddFlags(cmd.Flags())
+	r.printFlags.AddFlags(cmd)
+
+	cmdutil.AddDryRunFlag(cmd)
+
+	r.deleter.addFlags(cmd)
+	cmd.Flags().StringSliceVar(&r.includeNamespaces, "include-namespaces", nil, "Glob patterns of namespaces whose resources can be deleted. If empty, all namespaces are included.")
+	cmd.Flags().StringSliceVar(&r.excludeNamespaces, "exclude-namespaces", defaultExcludedNamespaces, "Glob patterns of namespaces whose resources are never deleted. Set to empty to exclude no namespaces.")
+	cmd.Flags().StringVar(&r.namespaceSelector, "namespace-selector", "", "Selector (label query) to filter namespaces on, supports '=', '==', and '!='.(e.g. --namespace-selector key1=value1,key2=value2)")
+
+	return cmd
+}
+
+func (r *applyPlanRunner) Validate() error {
+	return r.deleter.validate(r.ErrOut)
+}
+
+func (r *applyPlanRunner) Complete(f cmdutil.Factory, cmd *cobra.Command) (err error) {
+	r.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+	if err != nil {
+		return
+	}
+
+	r.printFlags = cmdutil.PrintFlagsWithDryRunStrategy(r.printFlags, r.dryRunStrategy)
+	r.printer, err = r.printFlags.ToPrinter()
+	if err != nil {
+		return
+	}
+	r.protectedPrinter, err = operationPrinter(r.printFlags, r.dryRunStrategy, printedOperationTypeProtected)
+	if err != nil {
+		return
+	}
+
+	if err = r.deleter.complete(f, r.dryRunStrategy, r.IOStreams); err != nil {
+		return
+	}
+	r.clientForMapping = f.UnstructuredClientForMapping
+
+	clientset, err := f.KubernetesClientSet()
+	if err != nil {
+		return
+	}
+	r.mapper, err = f.ToRESTMapper()
+	if err != nil {
+		return
+	}
+	resourceClient := resource.NewClient(clientset, r.deleter.dynamicClient, r.mapper)
+
+	r.protection = newProtection(resourceClient, r.ErrOut)
+
+	r.namespaceFilter, err = newNamespaceFilter(context.Background(), resourceClient, r.includeNamespaces, r.excludeNamespaces, r.namespaceSelector)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *applyPlanRunner) Run(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	p, err := plan.Read(f)
+	if err != nil {
+		return err
+	}
+
+	var (
+		errs    []error
+		changed int
+	)
+
+	for _, e := range p.Entries {
+		if !r.namespaceFilter.allows(e.Namespace) {
+			continue // ignore resources in excluded namespaces
+		}
+
+		result, err := r.apply(ctx, e)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s/%s: %w", e.Kind, e.Name, err))
+			continue
+		}
+		if result == applyResultChanged {
+			r.Errorf("%s/%s has changed since planning\n", strings.ToLower(e.Kind), e.Name)
+			changed++
+		}
+	}
+
+	r.deleter.wait()
+
+	if changed > 0 {
+		errs = append(errs, fmt.Errorf("%d resource(s) have changed since planning", changed))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// apply deletes the resource in the entry unless it has changed or been deleted since planning, or it's protected.
+func (r *applyPlanRunner) apply(ctx context.Context, e plan.Entry) (applyResult, error) {
+	gv, err := schema.ParseGroupVersion(e.APIVersion)
+	if err != nil {
+		return applyResultFailed, err
+	}
+	mapping, err := r.mapper.RESTMapping(gv.WithKind(e.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		return applyResultFailed, err
+	}
+
+	client, err := r.clientForMapping(mapping)
+	if err != nil {
+		return applyResultFailed, err
+	}
+	namespace := e.Namespace
+	if mapping.Scope.Name() != apimeta.RESTScopeNameNamespace {
+		namespace = ""
+	}
+
+	obj, err := cliresource.NewHelper(client, mapping).Get(namespace, e.Name)
+	if apierrors.IsNotFound(err) {
+		return applyResultChanged, nil
+	}
+	if err != nil {
+		return applyResultFailed, err
+	}
+	accessor, err := apimeta.Accessor(obj)
+	if err != nil {
+		return applyResultFailed, err
+	}
+	if accessor.GetResourceVersion() != e.ResourceVersion {
+		return applyResultChanged, nil
+	}
+
+	info := &cliresource.Info{
+		Client:    client,
+		Mapping:   mapping,
+		Namespace: namespace,
+		Name:      e.Name,
+		Object:    obj,
+	}
+
+	// The resource or its namespace can have been protected since planning.
+	protected, err := r.protection.protects(ctx, info)
+	if err != nil {
+		return applyResultFailed, err
+	}
+	if protected {
+		r.protectedPrinter.PrintObj(obj, r.Out)
+		return applyResultProtected, nil
+	}
+
+	if r.dryRunStrategy == cmdutil.DryRunClient {
+		r.printer.PrintObj(obj, r.Out)
+		return applyResultDeleted, nil
+	}
+	if err := r.deleter.verifyDryRun(info); err != nil {
+		return applyResultFailed, err
+	}
+
+	// The precondition prevents deleting the resource if it changes after being got.
+	if _, err := r.deleter.delete(info, &metav1.Preconditions{ResourceVersion: &e.ResourceVersion}); err != nil {
+		if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+			return applyResultChanged, nil
+		}
+		return applyResultFailed, err
+	}
+
+	r.printer.PrintObj(obj, r.Out)
+
+	return applyResultDeleted, nil
+}
+
+func (r *applyPlanRunner) Errorf(format string, a ...interface{}) {
+	fmt.Fprintf(r.ErrOut, format, a...)
+}
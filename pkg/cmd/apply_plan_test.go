@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/scheme"
+
+	"github.com/micnncim/kubectl-reap/pkg/plan"
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_applyPlanRunner_Run(t *testing.T) {
+	const fakeNamespace = "fake-ns"
+
+	newConfigMap := func(name, resourceVersion string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       fakeNamespace,
+				Name:            name,
+				ResourceVersion: resourceVersion,
+			},
+		}
+	}
+
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	// newRESTClient returns a client serving the ConfigMaps in the plan and recording the deleted ones.
+	newRESTClient := func(deleted map[string]bool) *fake.RESTClient {
+		configMaps := map[string]runtime.Object{
+			"fake-cm-unchanged": newConfigMap("fake-cm-unchanged", "1"),
+			"fake-cm-changed":   newConfigMap("fake-cm-changed", "2"),
+		}
+
+		return &fake.RESTClient{
+			NegotiatedSerializer: cliresource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+			Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				prefix := fmt.Sprintf("/namespaces/%s/configmaps/", fakeNamespace)
+				if !strings.HasPrefix(req.URL.Path, prefix) {
+					t.Errorf("unexpected request: %#v\n%#v", req.URL, req)
+					return nil, nil
+				}
+
+				name := strings.TrimPrefix(req.URL.Path, prefix)
+				obj, ok := configMaps[name]
+				if !ok {
+					return &http.Response{
+						StatusCode: http.StatusNotFound,
+						Header:     cmdtesting.DefaultHeader(),
+						Body:       cmdtesting.StringBody(""),
+					}, nil
+				}
+
+				switch req.Method {
+				case http.MethodGet:
+				case http.MethodDelete:
+					deleted[name] = true
+				default:
+					t.Errorf("unexpected request: %#v\n%#v", req.URL, req)
+					return nil, nil
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     cmdtesting.DefaultHeader(),
+					Body:       cmdtesting.ObjBody(codec, obj),
+				}, nil
+			}),
+		}
+	}
+
+	p := plan.New()
+	for _, e := range []plan.Entry{
+		{Name: "fake-cm-unchanged", ResourceVersion: "1"},
+		{Name: "fake-cm-changed", ResourceVersion: "1"},
+		{Name: "fake-cm-deleted", ResourceVersion: "1"},
+	} {
+		e.APIVersion = "v1"
+		e.Kind = "ConfigMap"
+		e.Namespace = fakeNamespace
+		p.Add(e)
+	}
+
+	planFile := filepath.Join(t.TempDir(), "plan.yaml")
+	f, err := os.Create(planFile)
+	if err != nil {
+		t.Fatalf("failed to create plan file: %v", err)
+	}
+	if err := p.Write(f); err != nil {
+		t.Fatalf("failed to write plan: %v", err)
+	}
+	f.Close()
+
+	fakeResourceClient, err := resource.NewFakeClient()
+	if err != nil {
+		t.Fatalf("failed to construct fake resource client")
+	}
+
+	fakeResourceClientWithProtectedNamespace, err := resource.NewFakeClient(
+		&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       resource.KindNamespace,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fakeNamespace,
+				Annotations: map[string]string{protectionKey: protectionValue},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to construct fake resource client")
+	}
+
+	tests := []struct {
+		name            string
+		dryRunStrategy  cmdutil.DryRunStrategy
+		namespaceFilter namespaceFilter
+		resourceClient  resource.Client
+		wantOut         string
+		wantErrOut      string
+		wantDeleted     bool
+		wantErr         bool
+	}{
+		{
+			name:           "only resources unchanged since planning should be deleted",
+			dryRunStrategy: cmdutil.DryRunNone,
+			resourceClient: fakeResourceClient,
+			wantOut:        "configmap/fake-cm-unchanged deleted\n",
+			wantErrOut: "configmap/fake-cm-changed has changed since planning\n" +
+				"configmap/fake-cm-deleted has changed since planning\n",
+			wantDeleted: true,
+			wantErr:     true,
+		},
+		{
+			name:           "no resources should be deleted in client-side dry-run",
+			dryRunStrategy: cmdutil.DryRunClient,
+			resourceClient: fakeResourceClient,
+			wantOut:        "configmap/fake-cm-unchanged deleted (dry run)\n",
+			wantErrOut: "configmap/fake-cm-changed has changed since planning\n" +
+				"configmap/fake-cm-deleted has changed since planning\n",
+			wantDeleted: false,
+			wantErr:     true,
+		},
+		{
+			name:           "resources in namespaces protected since planning should not be deleted",
+			dryRunStrategy: cmdutil.DryRunNone,
+			resourceClient: fakeResourceClientWithProtectedNamespace,
+			wantOut:        "configmap/fake-cm-unchanged protected\n",
+			wantErrOut: "configmap/fake-cm-changed has changed since planning\n" +
+				"configmap/fake-cm-deleted has changed since planning\n",
+			wantDeleted: false,
+			wantErr:     true,
+		},
+		{
+			name:           "resources in excluded namespaces should be ignored",
+			dryRunStrategy: cmdutil.DryRunNone,
+			namespaceFilter: namespaceFilter{
+				excludes: []string{"fake-*"},
+			},
+			resourceClient: fakeResourceClient,
+			wantOut:        "",
+			wantErrOut:     "",
+			wantDeleted:    false,
+			wantErr:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+
+			deleted := make(map[string]bool)
+
+			testFactory := cmdtesting.NewTestFactory().WithNamespace(fakeNamespace)
+			defer testFactory.Cleanup()
+			testFactory.UnstructuredClient = newRESTClient(deleted)
+
+			r := newApplyPlanRunner(streams)
+			r.dryRunStrategy = tt.dryRunStrategy
+			r.deleter = deleter{
+				dryRunStrategy: tt.dryRunStrategy,
+				streams:        streams,
+			}
+			r.clientForMapping = testFactory.UnstructuredClientForMapping
+			r.mapper = testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme)
+			r.namespaceFilter = tt.namespaceFilter
+			r.protection = newProtection(tt.resourceClient, errOut)
+
+			var err error
+			r.printFlags = cmdutil.PrintFlagsWithDryRunStrategy(r.printFlags, r.dryRunStrategy)
+			r.printer, err = r.printFlags.ToPrinter()
+			if err != nil {
+				t.Fatalf("failed to complete printer: %v", err)
+			}
+			r.protectedPrinter, err = operationPrinter(r.printFlags, r.dryRunStrategy, printedOperationTypeProtected)
+			if err != nil {
+				t.Fatalf("failed to complete printer: %v", err)
+			}
+
+			if err := r.Run(context.Background(), planFile); (err != nil) != tt.wantErr {
+				t.Errorf("applyPlanRunner.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantOut, out.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantErrOut, errOut.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			if got := deleted["fake-cm-unchanged"]; got != tt.wantDeleted {
+				t.Errorf("deleted = %v, want %v", got, tt.wantDeleted)
+			}
+			if deleted["fake-cm-changed"] {
+				t.Errorf("fake-cm-changed should not be deleted")
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// deletionBudget limits the number of resources deleted in a run.
+// The zero value allows any number of deletions.
+type deletionBudget struct {
+	maxDeletions       int // zero means no limit
+	maxDeletionPercent int // zero means no limit
+}
+
+func (b deletionBudget) validate() error {
+	if b.maxDeletions < 0 {
+		return errors.New("--max-deletions must not be negative")
+	}
+	if b.maxDeletionPercent < 0 || b.maxDeletionPercent > 100 {
+		return errors.New("--max-deletion-percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// check returns an error if deleting deletions out of total resources exceeds the budget.
+func (b deletionBudget) check(deletions, total int) error {
+	if b.maxDeletions > 0 && deletions > b.maxDeletions {
+		return fmt.Errorf("refusing to delete %d resource(s) which exceeds --max-deletions=%d", deletions, b.maxDeletions)
+	}
+	// compare deletions/total with maxDeletionPercent/100 without rounding
+	if b.maxDeletionPercent > 0 && deletions*100 > b.maxDeletionPercent*total {
+		return fmt.Errorf("refusing to delete %d of %d resource(s) which exceeds --max-deletion-percent=%d", deletions, total, b.maxDeletionPercent)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import "testing"
+
+func Test_deletionBudget_check(t *testing.T) {
+	tests := []struct {
+		name      string
+		budget    deletionBudget
+		deletions int
+		total     int
+		wantErr   bool
+	}{
+		{
+			name:      "zero value should allow any deletions",
+			budget:    deletionBudget{},
+			deletions: 10,
+			total:     10,
+			wantErr:   false,
+		},
+		{
+			name:      "deletions within the maximum should be allowed",
+			budget:    deletionBudget{maxDeletions: 3},
+			deletions: 3,
+			total:     10,
+			wantErr:   false,
+		},
+		{
+			name:      "deletions beyond the maximum should be refused",
+			budget:    deletionBudget{maxDeletions: 3},
+			deletions: 4,
+			total:     10,
+			wantErr:   true,
+		},
+		{
+			name:      "deletions within the maximum percent should be allowed",
+			budget:    deletionBudget{maxDeletionPercent: 50},
+			deletions: 5,
+			total:     10,
+			wantErr:   false,
+		},
+		{
+			name:      "deletions beyond the maximum percent should be refused",
+			budget:    deletionBudget{maxDeletionPercent: 50},
+			deletions: 6,
+			total:     11,
+			wantErr:   true,
+		},
+		{
+			name:      "no deletions should be allowed",
+			budget:    deletionBudget{maxDeletions: 1, maxDeletionPercent: 1},
+			deletions: 0,
+			total:     0,
+			wantErr:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.budget.check(tt.deletions, tt.total); (err != nil) != tt.wantErr {
+				t.Errorf("deletionBudget.check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -11,21 +11,21 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	cliresource "k8s.io/cli-runtime/pkg/resource"
-	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
-	cmdwait "k8s.io/kubectl/pkg/cmd/wait"
 
 	"github.com/micnncim/kubectl-reap/pkg/determiner"
+	"github.com/micnncim/kubectl-reap/pkg/plan"
 	"github.com/micnncim/kubectl-reap/pkg/prompt"
+	"github.com/micnncim/kubectl-reap/pkg/report"
 	"github.com/micnncim/kubectl-reap/pkg/resource"
 	"github.com/micnncim/kubectl-reap/pkg/version"
 )
@@ -34,14 +34,21 @@ const (
 	reapShortDescription = `
 Delete unused resources. Supported resources:
 
-- Pods (whose status is not Running)
-- ConfigMaps (not referenced by any Pods or ReplicaSets)
-- Secrets (not referenced by any Pods, ReplicaSets, or ServiceAccounts)
-- PersistentVolumes (not satisfying any PersistentVolumeClaims)
-- PersistentVolumeClaims (not referenced by any Pods)
-- Jobs (completed)
+- Pods (whose phase is Succeeded, Failed, Unknown, or Pending for a while)
+- ReplicaSets (without replicas and beyond revisionHistoryLimit of the Deployment, or orphaned from a missing Deployment)
+- ConfigMaps (not referenced by any Pods or workloads)
+- Secrets (not referenced by any Pods, workloads, ServiceAccounts, Ingresses, StorageClasses, or PersistentVolumes)
+- PersistentVolumes (Released, Failed, or bound to a missing PersistentVolumeClaim)
+- PersistentVolumeClaims (not referenced by any Pods or workloads)
+- Jobs (completed or failed, and not kept by TTL or CronJob history limits)
 - PodDisruptionBudgets (not targeting any Pods)
 - HorizontalPodAutoscalers (not targeting any resources)
+- Services (not selecting any Pods and without endpoints for a while)
+- ServiceAccounts (not referenced by any Pods, workloads, RoleBindings, or ClusterRoleBindings, except default)
+- Roles (not bound by any RoleBindings)
+- ClusterRoles (not bound by any RoleBindings or ClusterRoleBindings, nor aggregated, except system: ones)
+- RoleBindings (bound to a missing Role or ClusterRole, or every ServiceAccount subject is missing)
+- ClusterRoleBindings (bound to a missing ClusterRole, or every ServiceAccount subject is missing)
 `
 
 	reapExample = `
@@ -55,42 +62,92 @@ Delete unused resources. Supported resources:
   $ kubectl reap cm --all-namespaces
 
   # Delete Pods whose status is not Running as client-side dry-run
-  $ kubectl reap po --dry-run=client`
+  $ kubectl reap po --dry-run=client
+
+  # Delete unused Secrets across all namespaces except kube-* and the ones labeled team=platform
+  $ kubectl reap secrets -A --exclude-namespaces='kube-*' --namespace-selector='team!=platform'
+
+  # Delete unused ConfigMaps and Secrets created more than a day ago, and completed Jobs finished more than a week ago
+  $ kubectl reap cm,secret,job --older-than=24h --older-than-by-kind=Job=168h
+
+  # Explain why each Secret is or is not deleted without deleting them
+  $ kubectl reap secrets --dry-run=client --explain
+
+  # Write the report of reaping Pods as JSON to a file
+  $ kubectl reap po --report=json --report-file=report.json
+
+  # Back up unused Secrets to the directory before deleting them
+  $ kubectl reap secrets --backup-dir=./backup
+
+  # Delete unused ConfigMaps unless more than 10 of them or 20 percent of all ConfigMaps are to be deleted
+  $ kubectl reap cm --max-deletions=10 --max-deletion-percent=20
+
+  # Write the plan of deleting unused Secrets to a file, and apply it after reviewing it
+  $ kubectl reap secrets --plan-file=plan.yaml
+  $ kubectl reap apply-plan plan.yaml
+
+  # Choose unused Secrets to keep from the list of them with their reasons, and delete the others
+  $ kubectl reap secrets --pick
+
+  # Delete only evicted Pods and Pods pending for more than 10 minutes
+  $ kubectl reap po --pod-phases=Evicted,Pending --pending-older-than=10m`
 
 	// printedOperationTypeDeleted is used when printer outputs the result of operations.
 	printedOperationTypeDeleted = "deleted"
+	// printedOperationTypePlanned is used when printer outputs resources written to a plan.
+	printedOperationTypePlanned = "planned"
 )
 
-var timeWeek = 168 * time.Hour
-
 type runner struct {
 	configFlags *genericclioptions.ConfigFlags
 	printFlags  *genericclioptions.PrintFlags
 
-	namespace        string
-	allNamespaces    bool
-	chunkSize        int64
-	labelSelector    string
-	fieldSelector    string
-	gracePeriod      int
-	forceDeletion    bool
-	needWaitDeletion bool
-	timeout          time.Duration
+	namespace     string
+	allNamespaces bool
+	chunkSize     int64
+	labelSelector string
+	fieldSelector string
+
+	podPhases        []string
+	pendingOlderThan time.Duration
+
+	olderThan       time.Duration
+	olderThanByKind map[string]string
+
+	persistentVolumeCapacityHeuristic bool
+
+	serviceEmptyFor time.Duration
+
+	includeNamespaces []string
+	excludeNamespaces []string
+	namespaceSelector string
 
 	quiet       bool
 	interactive bool
+	pick        bool
+	explain     bool
+
+	reportFormat string
+	reportFile   string
+
+	budget deletionBudget
+
+	planFile string
 
 	showVersion bool
 
 	dryRunStrategy cmdutil.DryRunStrategy
-	dryRunVerifier *cliresource.DryRunVerifier
 
-	deleteOpts *metav1.DeleteOptions
+	deleter deleter
 
-	determiner    determiner.Determiner
-	dynamicClient dynamic.Interface
-	printer       printers.ResourcePrinter
-	result        *cliresource.Result
+	determiner       determiner.Determiner
+	namespaceFilter  namespaceFilter
+	protection       *protection
+	printer          printers.ResourcePrinter
+	protectedPrinter printers.ResourcePrinter
+	plannedPrinter   printers.ResourcePrinter
+	result           *cliresource.Result
+	report           *report.Report
 
 	genericclioptions.IOStreams
 }
@@ -111,6 +168,8 @@ func NewCmdReap(streams genericclioptions.IOStreams) *cobra.Command {
 		Use:     "kubectl reap RESOURCE_TYPE",
 		Short:   reapShortDescription,
 		Example: reapExample,
+		// Any resource types are accepted as arguments even though the command has subcommands.
+		Args: cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			if r.showVersion {
 				r.Infof("%s (%s)\n", version.Version, version.Revision)
@@ -141,32 +200,39 @@ func NewCmdReap(streams genericclioptions.IOStreams) *cobra.Command {
 
 	cmdutil.AddDryRunFlag(cmd)
 
-	cmd.Flags().BoolVarP(&r.allNamespaces, "all-namespaces", "A", false, "If true, delete the targeted resources across all namespace except kube-system")
+	cmd.AddCommand(NewCmdRestore(streams))
+	cmd.AddCommand(NewCmdApplyPlan(streams))
+
+	cmd.Flags().BoolVarP(&r.allNamespaces, "all-namespaces", "A", false, "If true, delete the targeted resources across all namespace except excluded ones")
 	cmd.Flags().StringVarP(&r.labelSelector, "selector", "l", "", "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	cmd.Flags().StringVar(&r.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
-	cmd.Flags().IntVar(&r.gracePeriod, "grace-period", -1, "Period of time in seconds given to the resource to terminate gracefully. Ignored if negative. Set to 1 for immediate shutdown. Can only be set to 0 when --force is true (force deletion).")
-	cmd.Flags().BoolVar(&r.forceDeletion, "force", false, "If true, immediately remove resources from API and bypass graceful deletion. Note that immediate deletion of some resources may result in inconsistency or data loss and requires confirmation.")
-	cmd.Flags().BoolVar(&r.needWaitDeletion, "wait", false, "If true, wait for resources to be gone before returning. This waits for finalizers.")
-	cmd.Flags().DurationVar(&r.timeout, "timeout", 0, "The length of time to wait before giving up on a delete, zero means determine a timeout from the size of the object")
+	r.deleter.addFlags(cmd)
+	cmd.Flags().StringSliceVar(&r.podPhases, "pod-phases", determiner.DefaultPodPhases, "Phases of Pods to be deleted. One of: Succeeded|Failed|Unknown|Pending|Evicted|OOMKilled. Evicted and OOMKilled select only the corresponding Failed Pods.")
+	cmd.Flags().DurationVar(&r.pendingOlderThan, "pending-older-than", determiner.DefaultPendingOlderThan, "The minimum age of Pending Pods to be deleted")
+	cmd.Flags().DurationVar(&r.olderThan, "older-than", 0, "The minimum age of resources to be deleted. The age of Pods and Jobs counts from their termination and completion. Zero means no minimum age.")
+	cmd.Flags().StringToStringVar(&r.olderThanByKind, "older-than-by-kind", nil, "The minimum age of resources to be deleted for each kind, which overrides --older-than (e.g. --older-than-by-kind ConfigMap=10m,Job=24h)")
+	cmd.Flags().BoolVar(&r.persistentVolumeCapacityHeuristic, "pv-capacity-heuristic", false, "If true, delete Available PersistentVolumes which can't satisfy any unbound PersistentVolumeClaims, or are pre-bound to missing ones")
+	cmd.Flags().DurationVar(&r.serviceEmptyFor, "service-empty-for", determiner.DefaultServiceEmptyFor, "The minimum period for which the EndpointSlices of Services to be deleted have been empty")
+	cmd.Flags().StringSliceVar(&r.includeNamespaces, "include-namespaces", nil, "Glob patterns of namespaces whose resources can be deleted. If empty, all namespaces are included.")
+	cmd.Flags().StringSliceVar(&r.excludeNamespaces, "exclude-namespaces", defaultExcludedNamespaces, "Glob patterns of namespaces whose resources are never deleted. Set to empty to exclude no namespaces.")
+	cmd.Flags().StringVar(&r.namespaceSelector, "namespace-selector", "", "Selector (label query) to filter namespaces on, supports '=', '==', and '!='.(e.g. --namespace-selector key1=value1,key2=value2)")
 	cmd.Flags().BoolVarP(&r.quiet, "quiet", "q", false, "If true, no output is produced")
-	cmd.Flags().BoolVarP(&r.interactive, "interactive", "i", false, "If true, a prompt asks whether resources can be deleted")
+	cmd.Flags().BoolVar(&r.explain, "explain", false, "If true, explain why each resource is or is not deleted")
+	cmd.Flags().StringVar(&r.reportFormat, "report", "", "Output format of the report of reaped resources. One of: json|yaml. If --report-file is not given, the report is written to stdout instead of the usual output.")
+	cmd.Flags().StringVar(&r.reportFile, "report-file", "", "Path to the file the report is written to")
+	cmd.Flags().IntVar(&r.budget.maxDeletions, "max-deletions", 0, "The maximum number of resources deleted in a run. If exceeded, no resources are deleted. Zero means no limit.")
+	cmd.Flags().IntVar(&r.budget.maxDeletionPercent, "max-deletion-percent", 0, "The maximum percentage of resources deleted out of the targeted ones in a run. If exceeded, no resources are deleted. Zero means no limit.")
+	cmd.Flags().StringVar(&r.planFile, "plan-file", "", "Path to the file the plan of resources to be deleted is written to instead of deleting them. The plan is applied later by the apply-plan command.")
+	cmd.Flags().BoolVarP(&r.interactive, "interactive", "i", false, "If true, a prompt lists resources to be deleted and asks once whether all, none, or selected ones can be deleted")
+	cmd.Flags().BoolVar(&r.pick, "pick", false, "If true, a filterable list of resources to be deleted with their reasons is shown to uncheck the ones which must stay")
 	cmd.Flags().BoolVar(&r.showVersion, "version", false, "If true, show the version of this plugin")
 
 	return cmd
 }
 
 func (r *runner) Complete(f cmdutil.Factory, args []string, cmd *cobra.Command) (err error) {
-	if !r.forceDeletion && r.gracePeriod == 0 {
-		// To preserve backwards compatibility, but prevent accidental data loss, we convert --grace-period=0
-		// into --grace-period=1. Users may provide --force to bypass this conversion.
-		r.gracePeriod = 1
-	}
-	if r.forceDeletion && r.gracePeriod < 0 {
-		r.gracePeriod = 0
-	}
-	r.deleteOpts = &metav1.DeleteOptions{}
-	if r.gracePeriod >= 0 {
-		r.deleteOpts = metav1.NewDeleteOptions(int64(r.gracePeriod))
+	if r.reportsToStdout() {
+		r.quiet = true // the report is written to stdout instead
 	}
 
 	r.namespace, _, err = r.configFlags.ToRawKubeConfigLoader().Namespace()
@@ -183,6 +249,10 @@ func (r *runner) Complete(f cmdutil.Factory, args []string, cmd *cobra.Command)
 		return
 	}
 
+	if err = r.deleter.complete(f, r.dryRunStrategy, r.IOStreams); err != nil {
+		return
+	}
+
 	if err = r.completeResources(f, args[0]); err != nil {
 		return
 	}
@@ -191,24 +261,38 @@ func (r *runner) Complete(f cmdutil.Factory, args []string, cmd *cobra.Command)
 	if err != nil {
 		return
 	}
-	r.dynamicClient, err = f.DynamicClient()
+	mapper, err := f.ToRESTMapper()
 	if err != nil {
 		return
 	}
-	resourceClient := resource.NewClient(clientset, r.dynamicClient)
+	resourceClient := resource.NewClient(clientset, r.deleter.dynamicClient, mapper)
 
-	discoveryClient, err := f.ToDiscoveryClient()
+	r.protection = newProtection(resourceClient, r.ErrOut)
+
+	r.namespaceFilter, err = newNamespaceFilter(context.Background(), resourceClient, r.includeNamespaces, r.excludeNamespaces, r.namespaceSelector)
 	if err != nil {
-		return err
+		return
 	}
-	r.dryRunVerifier = cliresource.NewDryRunVerifier(r.dynamicClient, discoveryClient)
 
 	namespace := r.namespace
 	if r.allNamespaces {
 		namespace = metav1.NamespaceAll
 	}
 
-	r.determiner, err = determiner.New(resourceClient, r.result, namespace)
+	olderThanByKind := make(map[string]time.Duration, len(r.olderThanByKind))
+	for kind, v := range r.olderThanByKind {
+		olderThanByKind[kind], err = time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid minimum age of %s: %w", kind, err)
+		}
+	}
+
+	r.determiner, err = determiner.New(resourceClient, r.result, namespace,
+		determiner.WithPodPolicy(r.podPhases, r.pendingOlderThan),
+		determiner.WithMinAge(r.olderThan, olderThanByKind),
+		determiner.WithPersistentVolumeCapacityHeuristic(r.persistentVolumeCapacityHeuristic),
+		determiner.WithServiceEmptyFor(r.serviceEmptyFor),
+	)
 	if err != nil {
 		return
 	}
@@ -224,9 +308,30 @@ func (r *runner) completePrinter() (err error) {
 		return err
 	}
 
+	r.protectedPrinter, err = operationPrinter(r.printFlags, r.dryRunStrategy, printedOperationTypeProtected)
+	if err != nil {
+		return err
+	}
+	r.plannedPrinter, err = operationPrinter(r.printFlags, r.dryRunStrategy, printedOperationTypePlanned)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// operationPrinter returns a printer for resources which are not deleted but on which the operation is done.
+// The resources are printed only when names are printed so as not to break structured output,
+// and the operation is suffixed in the same way as the deleted ones in dry-run.
+func operationPrinter(printFlags *genericclioptions.PrintFlags, dryRunStrategy cmdutil.DryRunStrategy, operation string) (printers.ResourcePrinter, error) {
+	if f := printFlags.OutputFormat; f != nil && *f != "" && *f != "name" {
+		return printers.NewDiscardingPrinter(), nil
+	}
+
+	operationFlags := genericclioptions.NewPrintFlags(operation).WithTypeSetter(scheme.Scheme)
+	return cmdutil.PrintFlagsWithDryRunStrategy(operationFlags, dryRunStrategy).ToPrinter()
+}
+
 func (r *runner) completeResources(f cmdutil.Factory, resourceTypes string) error {
 	r.result = f.
 		NewBuilder().
@@ -251,120 +356,306 @@ func (r *runner) Validate(args []string) error {
 		return errors.New("arguments must be only resource type(s)")
 	}
 
-	switch {
-	case r.forceDeletion && r.gracePeriod == 0:
-		r.Errorf("warning: Immediate deletion does not wait for confirmation that the running resource has been terminated. The resource may continue to run on the cluster indefinitely.\n")
-	case r.forceDeletion && r.gracePeriod > 0:
-		return fmt.Errorf("--force and --grace-period greater than 0 cannot be specified together")
+	if r.reportFormat != "" {
+		if _, err := report.ParseFormat(r.reportFormat); err != nil {
+			return err
+		}
+		if r.reportsToStdout() && r.explain {
+			return errors.New("--explain cannot be used with --report written to stdout, use --report-file")
+		}
+		if r.reportsToStdout() && (r.interactive || r.pick) {
+			return errors.New("--interactive and --pick cannot be used with --report written to stdout, use --report-file")
+		}
+	}
+	if r.reportFile != "" && r.reportFormat == "" {
+		return errors.New("--report-file requires --report")
 	}
 
-	return nil
+	if err := r.budget.validate(); err != nil {
+		return err
+	}
+
+	if r.planFile != "" && (r.interactive || r.pick) {
+		return errors.New("--plan-file cannot be used with --interactive or --pick")
+	}
+	if r.interactive && r.pick {
+		return errors.New("--interactive and --pick cannot be specified together")
+	}
+
+	return r.deleter.validate(r.ErrOut)
+}
+
+// candidate is a resource which should be deleted.
+type candidate struct {
+	info     *cliresource.Info
+	decision *determiner.Decision
 }
 
 func (r *runner) Run(ctx context.Context, f cmdutil.Factory) error {
-	deletedInfos := []*cliresource.Info{}
-	uidMap := cmdwait.UIDMap{}
+	if r.reportFormat != "" {
+		r.report = report.New(r.dryRunStrategy != cmdutil.DryRunNone)
+	}
 
-	if err := r.result.Visit(func(info *cliresource.Info, err error) error {
-		if info.Namespace == metav1.NamespaceSystem {
-			return nil // ignore resources in kube-system namespace
+	// Determine all the resources to be deleted before deleting any of them
+	// so that the deletion budget is checked against the whole run.
+	candidates, total, err := r.determineCandidates(ctx)
+	if err != nil {
+		return r.abort(err)
+	}
+	if err := r.budget.check(len(candidates), total); err != nil {
+		for _, c := range candidates {
+			r.record(c.info, report.StatusSkipped, c.decision, err)
 		}
+		return r.abort(err)
+	}
 
-		ok, err := r.determiner.DetermineDeletion(ctx, info)
-		if err != nil {
-			return err
+	if r.planFile != "" {
+		if err := r.writePlan(candidates); err != nil {
+			return r.abort(err)
 		}
-		if !ok {
-			return nil // skip deletion
+	}
+
+	switch {
+	case r.interactive || r.pick:
+		candidates = r.confirm(candidates) // the prompt displays the plan
+	case !r.quiet:
+		// Display the whole plan before applying it, or instead of applying it with --plan-file.
+		for _, c := range candidates {
+			r.printPlannedObj(c.info.Object)
 		}
+	}
 
-		if r.interactive {
-			kind := info.Object.GetObjectKind().GroupVersionKind().Kind
-			if ok := prompt.Confirm(fmt.Sprintf("Are you sure to delete %s/%s?", strings.ToLower(kind), info.Name)); !ok {
-				return nil // skip deletion
-			}
+	if r.planFile != "" {
+		for _, c := range candidates {
+			r.record(c.info, report.StatusCandidate, c.decision, nil)
 		}
+		return r.writeReport() // apply-plan deletes the resources later
+	}
 
-		deletedInfos = append(deletedInfos, info)
+	var errs []error
 
-		if r.dryRunStrategy == cmdutil.DryRunClient && !r.quiet {
-			r.printObj(info.Object)
-			return nil // skip deletion
-		}
-		if r.dryRunStrategy == cmdutil.DryRunServer {
-			if err := r.dryRunVerifier.HasSupport(info.Mapping.GroupVersionKind); err != nil {
-				return err
+	for _, c := range candidates {
+		info, decision := c.info, c.decision
+
+		if r.dryRunStrategy == cmdutil.DryRunClient {
+			if !r.quiet {
+				r.printObj(info.Object)
 			}
+			r.record(info, report.StatusCandidate, decision, nil)
+			continue // skip deletion
+		}
+		if err := r.deleter.verifyDryRun(info); err != nil {
+			return r.abort(err)
 		}
 
-		resp, err := cliresource.
-			NewHelper(info.Client, info.Mapping).
-			DryRun(r.dryRunStrategy == cmdutil.DryRunServer).
-			DeleteWithOptions(info.Namespace, info.Name, r.deleteOpts)
+		backupPath, err := r.deleter.delete(info, nil)
 		if err != nil {
-			return err
+			// continue deleting the other resources and report the failure
+			r.recordBackup(info, report.StatusFailed, decision, err, backupPath)
+			errs = append(errs, err)
+			continue
 		}
 
 		if !r.quiet {
 			r.printObj(info.Object)
 		}
+		r.recordBackup(info, report.StatusDeleted, decision, nil, backupPath)
+	}
+
+	if err := r.writeReport(); err != nil {
+		return err
+	}
 
-		loc := cmdwait.ResourceLocation{
-			GroupResource: info.Mapping.Resource.GroupResource(),
-			Namespace:     info.Namespace,
-			Name:          info.Name,
+	r.deleter.wait()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// determineCandidates returns the resources which should be deleted and aren't protected,
+// and the number of all the resources in the allowed namespaces.
+func (r *runner) determineCandidates(ctx context.Context) ([]candidate, int, error) {
+	var (
+		candidates []candidate
+		total      int
+	)
+
+	err := r.result.Visit(func(info *cliresource.Info, err error) error {
+		if !r.namespaceFilter.allows(info.Namespace) {
+			return nil // ignore resources in excluded namespaces
 		}
-		if status, ok := resp.(*metav1.Status); ok && status.Details != nil {
-			uidMap[loc] = status.Details.UID
-			return nil
+		total++
+
+		decision, err := r.determiner.DetermineDeletion(ctx, info)
+		if err != nil {
+			return err
+		}
+		if r.explain {
+			r.printDecision(info, decision)
+		}
+		if !decision.Delete {
+			r.record(info, report.StatusSkipped, decision, nil)
+			return nil // skip deletion
 		}
 
-		accessor, err := apimeta.Accessor(resp)
+		protected, err := r.protection.protects(ctx, info)
 		if err != nil {
-			// we don't have UID, but we didn't fail the delete, next best thing is just skipping the UID
-			r.Infof("%v\n", err)
-			return nil
+			return err
 		}
-		uidMap[loc] = accessor.GetUID()
+		if protected {
+			if !r.quiet {
+				r.printProtectedObj(info.Object)
+			}
+			r.record(info, report.StatusProtected, decision, nil)
+			return nil // skip deletion
+		}
+
+		candidates = append(candidates, candidate{info: info, decision: decision})
 
 		return nil
-	}); err != nil {
-		return err
+	})
+
+	return candidates, total, err
+}
+
+// confirm asks once which of the candidates can be deleted, and returns the confirmed ones.
+func (r *runner) confirm(candidates []candidate) []candidate {
+	items := make([]prompt.Item, 0, len(candidates))
+	for _, c := range candidates {
+		items = append(items, prompt.Item{
+			Kind:      c.info.Object.GetObjectKind().GroupVersionKind().Kind,
+			Namespace: c.info.Namespace,
+			Name:      c.info.Name,
+			Reason:    c.decision.Reason,
+		})
 	}
 
-	if !r.needWaitDeletion {
-		return nil
+	ask := prompt.ConfirmBatch
+	if r.pick {
+		ask = prompt.Pick
 	}
 
-	r.waitDeletion(uidMap, deletedInfos)
+	confirmed := make(map[int]struct{})
+	for _, i := range ask(items) {
+		confirmed[i] = struct{}{}
+	}
 
-	return nil
+	ret := make([]candidate, 0, len(confirmed))
+	for i, c := range candidates {
+		if _, ok := confirmed[i]; !ok {
+			r.record(c.info, report.StatusSkipped, &determiner.Decision{Reason: "declined in interactive mode"}, nil)
+			continue
+		}
+		ret = append(ret, c)
+	}
+
+	return ret
 }
 
-func (r *runner) waitDeletion(uidMap cmdwait.UIDMap, deletedInfos []*cliresource.Info) {
-	timeout := r.timeout
-	if timeout == 0 {
-		timeout = timeWeek
+// abort writes the report of the resources processed so far and returns err.
+func (r *runner) abort(err error) error {
+	if werr := r.writeReport(); werr != nil {
+		return werr
 	}
+	return err
+}
+
+// record adds the outcome of reaping the resource to the report if it's requested.
+func (r *runner) record(info *cliresource.Info, status report.Status, decision *determiner.Decision, err error) {
+	r.recordBackup(info, status, decision, err, "")
+}
 
-	waitOpts := cmdwait.WaitOptions{
-		ResourceFinder: genericclioptions.ResourceFinderForResult(cliresource.InfoListVisitor(deletedInfos)),
-		UIDMap:         uidMap,
-		DynamicClient:  r.dynamicClient,
-		Timeout:        timeout,
-		Printer:        printers.NewDiscardingPrinter(),
-		ConditionFn:    cmdwait.IsDeleted,
-		IOStreams:      r.IOStreams,
+// recordBackup is the same as record, but also adds the path to the backup of the resource.
+func (r *runner) recordBackup(info *cliresource.Info, status report.Status, decision *determiner.Decision, err error, backupPath string) {
+	if r.report == nil {
+		return
 	}
-	err := waitOpts.RunWait()
-	if apierrors.IsForbidden(err) || apierrors.IsMethodNotSupported(err) {
-		// if we're forbidden from waiting, we shouldn't fail.
-		// if the resource doesn't support a verb we need, we shouldn't fail.
-		r.Errorf("%v\n", err)
+
+	gvk := info.Object.GetObjectKind().GroupVersionKind()
+	e := report.Entry{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  info.Namespace,
+		Name:       info.Name,
+		Status:     status,
+		Reason:     decision.Reason,
+		Backup:     backupPath,
 	}
+	for _, ref := range decision.References {
+		e.References = append(e.References, ref.String())
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	r.report.Add(e)
 }
 
+func (r *runner) writePlan(candidates []candidate) (err error) {
+	p := plan.New()
+	for _, c := range candidates {
+		// Info.ResourceVersion of a listed resource is the one of the list.
+		accessor, err := apimeta.Accessor(c.info.Object)
+		if err != nil {
+			return err
+		}
+
+		gvk := c.info.Object.GetObjectKind().GroupVersionKind()
+		p.Add(plan.Entry{
+			APIVersion:      gvk.GroupVersion().String(),
+			Kind:            gvk.Kind,
+			Namespace:       c.info.Namespace,
+			Name:            c.info.Name,
+			ResourceVersion: accessor.GetResourceVersion(),
+			Reason:          c.decision.Reason,
+		})
+	}
+
+	f, err := os.Create(r.planFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	return p.Write(f)
+}
+
+func (r *runner) writeReport() (err error) {
+	if r.report == nil {
+		return nil
+	}
+
+	if r.reportsToStdout() {
+		return r.report.Write(r.Out, report.Format(r.reportFormat))
+	}
+
+	f, err := os.Create(r.reportFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	return r.report.Write(f, report.Format(r.reportFormat))
+}
+
+// reportsToStdout returns true if the report is written to stdout instead of the usual output.
+func (r *runner) reportsToStdout() bool {
+	return r.reportFormat != "" && r.reportFile == ""
+}
+
+// Infof writes the message to stdout, or to stderr not to corrupt the report written to stdout.
 func (r *runner) Infof(format string, a ...interface{}) {
+	if r.reportsToStdout() {
+		fmt.Fprintf(r.ErrOut, format, a...)
+		return
+	}
 	fmt.Fprintf(r.Out, format, a...)
 }
 
@@ -375,3 +666,20 @@ func (r *runner) Errorf(format string, a ...interface{}) {
 func (r *runner) printObj(obj runtime.Object) error {
 	return r.printer.PrintObj(obj, r.Out)
 }
+
+func (r *runner) printDecision(info *cliresource.Info, decision *determiner.Decision) {
+	kind := strings.ToLower(info.Object.GetObjectKind().GroupVersionKind().Kind)
+	if info.Namespace == "" {
+		r.Infof("%s/%s: %s\n", kind, info.Name, decision)
+		return
+	}
+	r.Infof("%s/%s (%s): %s\n", kind, info.Name, info.Namespace, decision)
+}
+
+func (r *runner) printProtectedObj(obj runtime.Object) error {
+	return r.protectedPrinter.PrintObj(obj, r.Out)
+}
+
+func (r *runner) printPlannedObj(obj runtime.Object) error {
+	return r.plannedPrinter.PrintObj(obj, r.Out)
+}
@@ -1,15 +1,21 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	cliresource "k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/rest/fake"
@@ -18,6 +24,9 @@ import (
 	"k8s.io/kubectl/pkg/scheme"
 
 	"github.com/micnncim/kubectl-reap/pkg/determiner"
+	"github.com/micnncim/kubectl-reap/pkg/plan"
+	"github.com/micnncim/kubectl-reap/pkg/report"
+	"github.com/micnncim/kubectl-reap/pkg/resource"
 )
 
 func Test_runner_Run(t *testing.T) {
@@ -30,6 +39,7 @@ func Test_runner_Run(t *testing.T) {
 		fakeObjectToBeDeleted1Name   = "fake-obj-to-be-deleted-1"
 		fakeObjectToBeDeleted2Name   = "fake-obj-to-be-deleted-2"
 		fakeObjectNotToBeDeletedName = "fake-obj-not-to-be-deleted"
+		fakeObjectProtectedName      = "fake-obj-protected"
 	)
 
 	fakeObjectBase := &corev1.Pod{
@@ -51,11 +61,16 @@ func Test_runner_Run(t *testing.T) {
 	fakeObjectNotToBeDeleted := fakeObjectBase.DeepCopy()
 	fakeObjectNotToBeDeleted.Name = fakeObjectNotToBeDeletedName
 
+	fakeObjectProtected := fakeObjectBase.DeepCopy()
+	fakeObjectProtected.Name = fakeObjectProtectedName
+	fakeObjectProtected.Annotations = map[string]string{protectionKey: protectionValue}
+
 	fakeObjectList := &corev1.PodList{
 		Items: []corev1.Pod{
 			*fakeObjectToBeDeleted1,
 			*fakeObjectToBeDeleted2,
 			*fakeObjectNotToBeDeleted,
+			*fakeObjectProtected,
 		},
 	}
 	fakeObjectMap := map[string]*corev1.Pod{
@@ -106,25 +121,99 @@ func Test_runner_Run(t *testing.T) {
 	fakeDeterminer, err := determiner.NewFakeDeterminer(
 		fakeObjectToBeDeleted1,
 		fakeObjectToBeDeleted2,
+		fakeObjectProtected,
 	)
 	if err != nil {
 		t.Fatalf("failed to construct fake determiner")
 	}
 
+	fakeResourceClient, err := resource.NewFakeClient()
+	if err != nil {
+		t.Fatalf("failed to construct fake resource client")
+	}
+
+	fakeResourceClientWithProtectedNamespace, err := resource.NewFakeClient(
+		&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       resource.KindNamespace,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fakeNamespace,
+				Labels: map[string]string{protectionKey: protectionValue},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to construct fake resource client")
+	}
+
+	fakeResourceClientForbiddenToGetNamespace, err := resource.NewFakeClient()
+	if err != nil {
+		t.Fatalf("failed to construct fake resource client")
+	}
+	fakeForbiddenErr := apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, fakeNamespace, errors.New("fake"))
+	fakeResourceClientForbiddenToGetNamespace.SetError(resource.KindNamespace, fakeForbiddenErr)
+
 	type fields struct {
-		dryRunStrategy cmdutil.DryRunStrategy
+		dryRunStrategy  cmdutil.DryRunStrategy
+		namespaceFilter namespaceFilter
+		resourceClient  resource.Client
+		explain         bool
+		quiet           bool
+		reportFormat    string
+		backup          bool
+		budget          deletionBudget
+		plan            bool
+	}
+
+	wantReport := report.New(true)
+	for _, e := range []report.Entry{
+		{Name: fakeObjectNotToBeDeletedName, Status: report.StatusSkipped, Reason: "not to be deleted"},
+		{Name: fakeObjectProtectedName, Status: report.StatusProtected, Reason: "to be deleted"},
+		{Name: fakeObjectToBeDeleted1Name, Status: report.StatusCandidate, Reason: "to be deleted"},
+		{Name: fakeObjectToBeDeleted2Name, Status: report.StatusCandidate, Reason: "to be deleted"},
+	} {
+		e.APIVersion = fakeAPIVersion
+		e.Kind = fakeKind
+		e.Namespace = fakeNamespace
+		wantReport.Add(e)
+	}
+	var wantReportOut bytes.Buffer
+	if err := wantReport.Write(&wantReportOut, report.FormatJSON); err != nil {
+		t.Fatalf("failed to write report: %v", err)
 	}
 
 	tests := []struct {
-		name    string
-		fields  fields
-		wantOut string
-		wantErr bool
+		name        string
+		fields      fields
+		wantOut     string
+		wantErrOut  string
+		wantBackups []string
+		wantPlan    []string
+		wantErr     bool
 	}{
 		{
-			name:   "delete resources that should be deleted",
-			fields: fields{},
+			name: "delete resources that should be deleted",
+			fields: fields{
+				resourceClient: fakeResourceClient,
+			},
 			wantOut: makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectProtectedName,
+				},
+				printedOperationTypeProtected,
+				cmdutil.DryRunNone,
+			) + makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectToBeDeleted1Name,
+					fakeObjectToBeDeleted2Name,
+				},
+				printedOperationTypePlanned,
+				cmdutil.DryRunNone,
+			) + makeOperationMessage(
 				fakeResourceType,
 				[]string{
 					fakeObjectToBeDeleted1Name,
@@ -139,8 +228,24 @@ func Test_runner_Run(t *testing.T) {
 			name: "does not delete resources that should be deleted when dry-run is set as client",
 			fields: fields{
 				dryRunStrategy: cmdutil.DryRunClient,
+				resourceClient: fakeResourceClient,
 			},
 			wantOut: makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectProtectedName,
+				},
+				printedOperationTypeProtected,
+				cmdutil.DryRunClient,
+			) + makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectToBeDeleted1Name,
+					fakeObjectToBeDeleted2Name,
+				},
+				printedOperationTypePlanned,
+				cmdutil.DryRunClient,
+			) + makeOperationMessage(
 				fakeResourceType,
 				[]string{
 					fakeObjectToBeDeleted1Name,
@@ -151,21 +256,208 @@ func Test_runner_Run(t *testing.T) {
 			),
 			wantErr: false,
 		},
+		{
+			name: "does not delete resources in excluded namespaces",
+			fields: fields{
+				namespaceFilter: namespaceFilter{
+					excludes: []string{"fake-*"},
+				},
+				resourceClient: fakeResourceClient,
+			},
+			wantOut: "",
+			wantErr: false,
+		},
+		{
+			name: "explain decisions of all resources",
+			fields: fields{
+				dryRunStrategy: cmdutil.DryRunClient,
+				resourceClient: fakeResourceClient,
+				explain:        true,
+			},
+			wantOut: strings.Join([]string{
+				fmt.Sprintf("%s/%s (%s): delete (to be deleted)", fakeResourceType, fakeObjectToBeDeleted1Name, fakeNamespace),
+				fmt.Sprintf("%s/%s (%s): delete (to be deleted)", fakeResourceType, fakeObjectToBeDeleted2Name, fakeNamespace),
+				fmt.Sprintf("%s/%s (%s): keep (not to be deleted)", fakeResourceType, fakeObjectNotToBeDeletedName, fakeNamespace),
+				fmt.Sprintf("%s/%s (%s): delete (to be deleted)", fakeResourceType, fakeObjectProtectedName, fakeNamespace),
+				fmt.Sprintf("%s/%s %s (dry run)", fakeResourceType, fakeObjectProtectedName, printedOperationTypeProtected),
+				fmt.Sprintf("%s/%s %s (dry run)", fakeResourceType, fakeObjectToBeDeleted1Name, printedOperationTypePlanned),
+				fmt.Sprintf("%s/%s %s (dry run)", fakeResourceType, fakeObjectToBeDeleted2Name, printedOperationTypePlanned),
+				fmt.Sprintf("%s/%s %s (dry run)", fakeResourceType, fakeObjectToBeDeleted1Name, printedOperationTypeDeleted),
+				fmt.Sprintf("%s/%s %s (dry run)", fakeResourceType, fakeObjectToBeDeleted2Name, printedOperationTypeDeleted),
+			}, "\n") + "\n",
+			wantErr: false,
+		},
+		{
+			name: "write report instead of usual output",
+			fields: fields{
+				dryRunStrategy: cmdutil.DryRunClient,
+				resourceClient: fakeResourceClient,
+				quiet:          true,
+				reportFormat:   string(report.FormatJSON),
+			},
+			wantOut: wantReportOut.String(),
+			wantErr: false,
+		},
+		{
+			name: "back up resources before deleting them",
+			fields: fields{
+				resourceClient: fakeResourceClient,
+				quiet:          true,
+				backup:         true,
+			},
+			wantOut: "",
+			wantBackups: []string{
+				filepath.Join(fakeNamespace, fakeResourceType, fakeObjectToBeDeleted1Name+".yaml"),
+				filepath.Join(fakeNamespace, fakeResourceType, fakeObjectToBeDeleted2Name+".yaml"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "write plan instead of deleting resources",
+			fields: fields{
+				resourceClient: fakeResourceClient,
+				plan:           true,
+			},
+			wantOut: makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectProtectedName,
+				},
+				printedOperationTypeProtected,
+				cmdutil.DryRunNone,
+			) + makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectToBeDeleted1Name,
+					fakeObjectToBeDeleted2Name,
+				},
+				printedOperationTypePlanned,
+				cmdutil.DryRunNone,
+			),
+			wantPlan: []string{
+				fakeObjectToBeDeleted1Name,
+				fakeObjectToBeDeleted2Name,
+			},
+			wantErr: false,
+		},
+		{
+			name: "does not delete any resources when deletions exceed the maximum",
+			fields: fields{
+				resourceClient: fakeResourceClient,
+				budget:         deletionBudget{maxDeletions: 1},
+			},
+			wantOut: makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectProtectedName,
+				},
+				printedOperationTypeProtected,
+				cmdutil.DryRunNone,
+			),
+			wantErr: true,
+		},
+		{
+			name: "does not delete any resources when deletions exceed the maximum percent",
+			fields: fields{
+				dryRunStrategy: cmdutil.DryRunClient,
+				resourceClient: fakeResourceClient,
+				budget:         deletionBudget{maxDeletionPercent: 25},
+			},
+			wantOut: makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectProtectedName,
+				},
+				printedOperationTypeProtected,
+				cmdutil.DryRunClient,
+			),
+			wantErr: true,
+		},
+		{
+			name: "does not delete resources in protected namespaces",
+			fields: fields{
+				resourceClient: fakeResourceClientWithProtectedNamespace,
+			},
+			wantOut: makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectToBeDeleted1Name,
+					fakeObjectToBeDeleted2Name,
+					fakeObjectProtectedName,
+				},
+				printedOperationTypeProtected,
+				cmdutil.DryRunNone,
+			),
+			wantErr: false,
+		},
+		{
+			name: "delete resources only with their own protection when getting namespaces is forbidden",
+			fields: fields{
+				resourceClient: fakeResourceClientForbiddenToGetNamespace,
+			},
+			wantOut: makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectProtectedName,
+				},
+				printedOperationTypeProtected,
+				cmdutil.DryRunNone,
+			) + makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectToBeDeleted1Name,
+					fakeObjectToBeDeleted2Name,
+				},
+				printedOperationTypePlanned,
+				cmdutil.DryRunNone,
+			) + makeOperationMessage(
+				fakeResourceType,
+				[]string{
+					fakeObjectToBeDeleted1Name,
+					fakeObjectToBeDeleted2Name,
+				},
+				printedOperationTypeDeleted,
+				cmdutil.DryRunNone,
+			),
+			wantErrOut: fmt.Sprintf("warning: Protection of namespaces is not checked since getting Namespaces is forbidden: %v\n", fakeForbiddenErr),
+			wantErr:    false,
+		},
 	}
 
 	for _, tt := range tests {
 		tt := tt
 
 		t.Run(tt.name, func(t *testing.T) {
-			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+
+			var backupDir string
+			if tt.fields.backup {
+				backupDir = t.TempDir()
+			}
+
+			var planFile string
+			if tt.fields.plan {
+				planFile = filepath.Join(t.TempDir(), "plan.yaml")
+			}
 
 			r := &runner{
-				printFlags:     genericclioptions.NewPrintFlags(printedOperationTypeDeleted).WithTypeSetter(scheme.Scheme),
-				namespace:      fakeNamespace,
-				chunkSize:      10,
-				determiner:     fakeDeterminer,
-				dryRunStrategy: tt.fields.dryRunStrategy,
-				IOStreams:      streams,
+				printFlags:      genericclioptions.NewPrintFlags(printedOperationTypeDeleted).WithTypeSetter(scheme.Scheme),
+				namespace:       fakeNamespace,
+				chunkSize:       10,
+				determiner:      fakeDeterminer,
+				dryRunStrategy:  tt.fields.dryRunStrategy,
+				namespaceFilter: tt.fields.namespaceFilter,
+				protection:      newProtection(tt.fields.resourceClient, errOut),
+				explain:         tt.fields.explain,
+				quiet:           tt.fields.quiet,
+				reportFormat:    tt.fields.reportFormat,
+				deleter: deleter{
+					backupDir:      backupDir,
+					dryRunStrategy: tt.fields.dryRunStrategy,
+				},
+				budget:    tt.fields.budget,
+				planFile:  planFile,
+				IOStreams: streams,
 			}
 
 			if err := r.completePrinter(); err != nil {
@@ -187,6 +479,118 @@ func Test_runner_Run(t *testing.T) {
 				t.Errorf("(-want +got):\n%s", diff)
 				return
 			}
+			if diff := cmp.Diff(tt.wantErrOut, errOut.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+				return
+			}
+
+			for _, path := range tt.wantBackups {
+				if _, err := os.Stat(filepath.Join(backupDir, path)); err != nil {
+					t.Errorf("failed to find backup: %v", err)
+				}
+			}
+
+			if planFile == "" {
+				return
+			}
+			f, err := os.Open(planFile)
+			if err != nil {
+				t.Errorf("failed to open plan: %v", err)
+				return
+			}
+			defer f.Close()
+			p, err := plan.Read(f)
+			if err != nil {
+				t.Errorf("failed to read plan: %v", err)
+				return
+			}
+			var gotPlan []string
+			for _, e := range p.Entries {
+				gotPlan = append(gotPlan, e.Name)
+			}
+			if diff := cmp.Diff(tt.wantPlan, gotPlan); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_runner_Validate(t *testing.T) {
+	type fields struct {
+		reportFormat string
+		reportFile   string
+		explain      bool
+		interactive  bool
+		pick         bool
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "report written to stdout should be accepted",
+			fields: fields{
+				reportFormat: string(report.FormatJSON),
+			},
+			wantErr: false,
+		},
+		{
+			name: "explanation should be rejected with report written to stdout",
+			fields: fields{
+				reportFormat: string(report.FormatJSON),
+				explain:      true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "interactive mode should be rejected with report written to stdout",
+			fields: fields{
+				reportFormat: string(report.FormatYAML),
+				interactive:  true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "picker should be rejected with report written to stdout",
+			fields: fields{
+				reportFormat: string(report.FormatJSON),
+				pick:         true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "interactive mode should be accepted with report written to a file",
+			fields: fields{
+				reportFormat: string(report.FormatJSON),
+				reportFile:   "report.json",
+				interactive:  true,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+			r := &runner{
+				reportFormat: tt.fields.reportFormat,
+				reportFile:   tt.fields.reportFile,
+				explain:      tt.fields.explain,
+				interactive:  tt.fields.interactive,
+				pick:         tt.fields.pick,
+				IOStreams:    streams,
+			}
+
+			if err := r.Validate([]string{"pods"}); (err != nil) != tt.wantErr {
+				t.Errorf("runner.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
 		})
 	}
 }
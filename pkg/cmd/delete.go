@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/dynamic"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	cmdwait "k8s.io/kubectl/pkg/cmd/wait"
+
+	"github.com/micnncim/kubectl-reap/pkg/backup"
+)
+
+var timeWeek = 168 * time.Hour
+
+// deleter deletes resources in the same way for the reap and apply-plan commands.
+// It backs resources up before deleting them, and waits for the deleted ones to be gone if requested.
+type deleter struct {
+	gracePeriod      int
+	forceDeletion    bool
+	needWaitDeletion bool
+	timeout          time.Duration
+	backupDir        string
+
+	dryRunStrategy cmdutil.DryRunStrategy
+	dryRunVerifier *cliresource.DryRunVerifier
+	deleteOpts     *metav1.DeleteOptions
+	dynamicClient  dynamic.Interface
+
+	deletedInfos []*cliresource.Info
+	uidMap       cmdwait.UIDMap
+
+	streams genericclioptions.IOStreams
+}
+
+func (d *deleter) addFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&d.gracePeriod, "grace-period", -1, "Period of time in seconds given to the resource to terminate gracefully. Ignored if negative. Set to 1 for immediate shutdown. Can only be set to 0 when --force is true (force deletion).")
+	cmd.Flags().BoolVar(&d.forceDeletion, "force", false, "If true, immediately remove resources from API and bypass graceful deletion. Note that immediate deletion of some resources may result in inconsistency or data loss and requires confirmation.")
+	cmd.Flags().BoolVar(&d.needWaitDeletion, "wait", false, "If true, wait for resources to be gone before returning. This waits for finalizers.")
+	cmd.Flags().DurationVar(&d.timeout, "timeout", 0, "The length of time to wait before giving up on a delete, zero means determine a timeout from the size of the object")
+	cmd.Flags().StringVar(&d.backupDir, "backup-dir", "", "Path to the directory the manifests of resources are written to before they're deleted, organized by namespace and kind")
+}
+
+func (d *deleter) validate(errOut io.Writer) error {
+	switch {
+	case d.forceDeletion && d.gracePeriod == 0:
+		fmt.Fprintf(errOut, "warning: Immediate deletion does not wait for confirmation that the running resource has been terminated. The resource may continue to run on the cluster indefinitely.\n")
+	case d.forceDeletion && d.gracePeriod > 0:
+		return fmt.Errorf("--force and --grace-period greater than 0 cannot be specified together")
+	}
+
+	return nil
+}
+
+func (d *deleter) complete(f cmdutil.Factory, dryRunStrategy cmdutil.DryRunStrategy, streams genericclioptions.IOStreams) (err error) {
+	if !d.forceDeletion && d.gracePeriod == 0 {
+		// To preserve backwards compatibility, but prevent accidental data loss, we convert --grace-period=0
+		// into --grace-period=1. Users may provide --force to bypass this conversion.
+		d.gracePeriod = 1
+	}
+	if d.forceDeletion && d.gracePeriod < 0 {
+		d.gracePeriod = 0
+	}
+
+	d.deleteOpts = &metav1.DeleteOptions{}
+	if d.gracePeriod >= 0 {
+		d.deleteOpts = metav1.NewDeleteOptions(int64(d.gracePeriod))
+	}
+
+	d.dryRunStrategy = dryRunStrategy
+	d.streams = streams
+
+	d.dynamicClient, err = f.DynamicClient()
+	if err != nil {
+		return
+	}
+	discoveryClient, err := f.ToDiscoveryClient()
+	if err != nil {
+		return
+	}
+	d.dryRunVerifier = cliresource.NewDryRunVerifier(d.dynamicClient, discoveryClient)
+
+	return
+}
+
+// verifyDryRun returns an error if the resource doesn't support server-side dry-run when it's requested.
+func (d *deleter) verifyDryRun(info *cliresource.Info) error {
+	if d.dryRunStrategy != cmdutil.DryRunServer {
+		return nil
+	}
+	return d.dryRunVerifier.HasSupport(info.Mapping.GroupVersionKind)
+}
+
+// delete backs up the resource and deletes it, and returns the path to the backup if any.
+// The preconditions, if not nil, prevent deleting the resource when it doesn't satisfy them.
+func (d *deleter) delete(info *cliresource.Info, preconditions *metav1.Preconditions) (string, error) {
+	var backupPath string
+	if d.backupDir != "" && d.dryRunStrategy == cmdutil.DryRunNone {
+		var err error
+		backupPath, err = backup.Write(d.backupDir, info.Object)
+		if err != nil {
+			// never delete resources which can't be backed up
+			return "", fmt.Errorf("failed to back up %s/%s: %w", info.Mapping.GroupVersionKind.Kind, info.Name, err)
+		}
+	}
+
+	opts := &metav1.DeleteOptions{}
+	if d.deleteOpts != nil {
+		*opts = *d.deleteOpts
+	}
+	opts.Preconditions = preconditions
+
+	resp, err := cliresource.
+		NewHelper(info.Client, info.Mapping).
+		DryRun(d.dryRunStrategy == cmdutil.DryRunServer).
+		DeleteWithOptions(info.Namespace, info.Name, opts)
+	if err != nil {
+		return backupPath, err
+	}
+
+	// only the resources actually deleted are waited for
+	if d.dryRunStrategy != cmdutil.DryRunNone {
+		return backupPath, nil
+	}
+	d.deletedInfos = append(d.deletedInfos, info)
+
+	if d.uidMap == nil {
+		d.uidMap = cmdwait.UIDMap{}
+	}
+	loc := cmdwait.ResourceLocation{
+		GroupResource: info.Mapping.Resource.GroupResource(),
+		Namespace:     info.Namespace,
+		Name:          info.Name,
+	}
+	if status, ok := resp.(*metav1.Status); ok && status.Details != nil {
+		d.uidMap[loc] = status.Details.UID
+		return backupPath, nil
+	}
+
+	accessor, err := apimeta.Accessor(resp)
+	if err != nil {
+		// we don't have UID, but we didn't fail the delete, next best thing is just skipping the UID
+		fmt.Fprintf(d.streams.ErrOut, "%v\n", err)
+		return backupPath, nil
+	}
+	d.uidMap[loc] = accessor.GetUID()
+
+	return backupPath, nil
+}
+
+// wait waits for the deleted resources to be gone if requested.
+func (d *deleter) wait() {
+	if !d.needWaitDeletion || len(d.deletedInfos) == 0 {
+		return
+	}
+
+	timeout := d.timeout
+	if timeout == 0 {
+		timeout = timeWeek
+	}
+
+	waitOpts := cmdwait.WaitOptions{
+		ResourceFinder: genericclioptions.ResourceFinderForResult(cliresource.InfoListVisitor(d.deletedInfos)),
+		UIDMap:         d.uidMap,
+		DynamicClient:  d.dynamicClient,
+		Timeout:        timeout,
+		Printer:        printers.NewDiscardingPrinter(),
+		ConditionFn:    cmdwait.IsDeleted,
+		IOStreams:      d.streams,
+	}
+	err := waitOpts.RunWait()
+	if apierrors.IsForbidden(err) || apierrors.IsMethodNotSupported(err) {
+		// if we're forbidden from waiting, we shouldn't fail.
+		// if the resource doesn't support a verb we need, we shouldn't fail.
+		fmt.Fprintf(d.streams.ErrOut, "%v\n", err)
+	}
+}
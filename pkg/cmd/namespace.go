@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+// defaultExcludedNamespaces is the list of namespaces managed by Kubernetes itself.
+var defaultExcludedNamespaces = []string{
+	metav1.NamespaceSystem,
+	metav1.NamespacePublic,
+	corev1.NamespaceNodeLease,
+}
+
+// namespaceFilter filters resources by their namespaces.
+// The zero value allows all namespaces.
+type namespaceFilter struct {
+	includes []string            // glob patterns
+	excludes []string            // glob patterns
+	selected map[string]struct{} // nil if no namespace selector is given
+}
+
+// newNamespaceFilter returns a namespaceFilter allowing namespaces which match any of includes,
+// don't match any of excludes, and have labels matching selector.
+// Empty includes and an empty selector allow all namespaces.
+func newNamespaceFilter(ctx context.Context, c resource.Client, includes, excludes []string, selector string) (namespaceFilter, error) {
+	for _, pattern := range append(append([]string{}, includes...), excludes...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return namespaceFilter{}, fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+	}
+
+	f := namespaceFilter{
+		includes: includes,
+		excludes: excludes,
+	}
+
+	if selector == "" {
+		return f, nil
+	}
+
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return namespaceFilter{}, err
+	}
+
+	nss, err := c.ListNamespaces(ctx)
+	if err != nil {
+		return namespaceFilter{}, err
+	}
+
+	f.selected = make(map[string]struct{})
+	for _, ns := range nss {
+		if s.Matches(labels.Set(ns.Labels)) {
+			f.selected[ns.Name] = struct{}{}
+		}
+	}
+
+	return f, nil
+}
+
+// allows returns true if resources in the given namespace can be reaped.
+// Cluster-scoped resources are always allowed.
+func (f namespaceFilter) allows(namespace string) bool {
+	if namespace == "" {
+		return true
+	}
+
+	if len(f.includes) > 0 && !matchNamespace(f.includes, namespace) {
+		return false
+	}
+
+	if matchNamespace(f.excludes, namespace) {
+		return false
+	}
+
+	if f.selected != nil {
+		_, ok := f.selected[namespace]
+		return ok
+	}
+
+	return true
+}
+
+func matchNamespace(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		// Patterns are validated in newNamespaceFilter.
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_namespaceFilter_allows(t *testing.T) {
+	fakeClient, err := resource.NewFakeClient(
+		&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       resource.KindNamespace,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{"team": "a"},
+			},
+		},
+		&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       resource.KindNamespace,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-b",
+				Labels: map[string]string{"team": "b"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to construct fake client: %v", err)
+	}
+
+	type fields struct {
+		includes []string
+		excludes []string
+		selector string
+	}
+
+	tests := []struct {
+		name      string
+		fields    fields
+		namespace string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "any namespace should be allowed by zero value",
+			fields:    fields{},
+			namespace: metav1.NamespaceSystem,
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name: "default excluded namespace should not be allowed",
+			fields: fields{
+				excludes: defaultExcludedNamespaces,
+			},
+			namespace: corev1.NamespaceNodeLease,
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name: "cluster-scoped resources should be allowed",
+			fields: fields{
+				includes: []string{"team-*"},
+				excludes: []string{"*"},
+			},
+			namespace: "",
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name: "namespace matching glob pattern of excludes should not be allowed",
+			fields: fields{
+				excludes: []string{"*-system"},
+			},
+			namespace: "istio-system",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name: "namespace not matching includes should not be allowed",
+			fields: fields{
+				includes: []string{"team-*"},
+			},
+			namespace: "monitoring",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name: "namespace matching both includes and excludes should not be allowed",
+			fields: fields{
+				includes: []string{"team-*"},
+				excludes: []string{"team-b"},
+			},
+			namespace: "team-b",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name: "namespace selected by selector should be allowed",
+			fields: fields{
+				selector: "team=a",
+			},
+			namespace: "team-a",
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name: "namespace not selected by selector should not be allowed",
+			fields: fields{
+				selector: "team=a",
+			},
+			namespace: "team-b",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name: "invalid pattern should cause error",
+			fields: fields{
+				excludes: []string{"["},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := newNamespaceFilter(context.Background(), fakeClient, tt.fields.includes, tt.fields.excludes, tt.fields.selector)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newNamespaceFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if got := f.allows(tt.namespace); got != tt.want {
+				t.Errorf("namespaceFilter.allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
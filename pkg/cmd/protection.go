@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+const (
+	// protectionKey is the annotation or label key to opt resources out of reaping.
+	// Resources, or resources in namespaces, whose annotation or label has the value "true" are never deleted.
+	protectionKey   = "reap.kubectl.io/protect"
+	protectionValue = "true"
+
+	// printedOperationTypeProtected is used when printer outputs resources protected from deletion.
+	printedOperationTypeProtected = "protected"
+)
+
+// protection determines whether resources are protected from deletion.
+type protection struct {
+	resourceClient resource.Client
+	errOut         io.Writer
+
+	namespaces map[string]bool // key=Namespace.Name, value=whether Namespace is protected
+	warned     bool            // whether it has been warned that Namespaces can't be got
+}
+
+func newProtection(resourceClient resource.Client, errOut io.Writer) *protection {
+	return &protection{
+		resourceClient: resourceClient,
+		errOut:         errOut,
+		namespaces:     make(map[string]bool),
+	}
+}
+
+// protects returns true if the resource or its namespace is protected.
+func (p *protection) protects(ctx context.Context, info *cliresource.Info) (bool, error) {
+	ok, err := isProtected(info.Object)
+	if err != nil || ok {
+		return ok, err
+	}
+
+	if info.Namespace == "" {
+		return false, nil
+	}
+
+	return p.protectsNamespace(ctx, info.Namespace)
+}
+
+func (p *protection) protectsNamespace(ctx context.Context, namespace string) (bool, error) {
+	if ok, cached := p.namespaces[namespace]; cached {
+		return ok, nil
+	}
+
+	ns, err := p.resourceClient.GetUnstructured(ctx, "v1", resource.KindNamespace, namespace, "")
+	switch {
+	case apierrors.IsForbidden(err):
+		// Users whose access is limited to namespaces commonly can't get Namespaces.
+		// Fall back to the protection of resources themselves rather than reaping nothing.
+		if !p.warned {
+			fmt.Fprintf(p.errOut, "warning: Protection of namespaces is not checked since getting Namespaces is forbidden: %v\n", err)
+			p.warned = true
+		}
+		p.namespaces[namespace] = false
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	var ok bool
+	if ns != nil {
+		ok, err = isProtected(ns)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	p.namespaces[namespace] = ok
+
+	return ok, nil
+}
+
+func isProtected(obj runtime.Object) (bool, error) {
+	accessor, err := apimeta.Accessor(obj)
+	if err != nil {
+		return false, err
+	}
+
+	return accessor.GetAnnotations()[protectionKey] == protectionValue ||
+		accessor.GetLabels()[protectionKey] == protectionValue, nil
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+
+	"github.com/micnncim/kubectl-reap/pkg/report"
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+const (
+	restoreShortDescription = `
+Re-create resources from a backup directory written by --backup-dir, or from a report written by --report
+whose deleted entries have backups. Resources are created in dependency order, e.g. PersistentVolumes
+before PersistentVolumeClaims and ServiceAccounts before Secrets.
+`
+
+	restoreExample = `
+  # Restore resources backed up to the directory
+  $ kubectl reap restore ./backup
+
+  # Restore resources deleted in the report as client-side dry-run
+  $ kubectl reap restore report.json --dry-run=client
+
+  # Restore resources in the namespace/old-namespace into the namespace/new-namespace
+  $ kubectl reap restore ./backup --namespace-mapping old-namespace=new-namespace`
+
+	// printedOperationTypeCreated is used when printer outputs the result of restoring.
+	printedOperationTypeCreated = "created"
+)
+
+// restoreOrder is the list of kinds in the order they are restored. Other kinds are restored last.
+var restoreOrder = []string{
+	resource.KindPersistentVolume,
+	resource.KindPersistentVolumeClaim,
+	resource.KindServiceAccount,
+	resource.KindSecret,
+	resource.KindConfigMap,
+}
+
+type restoreRunner struct {
+	configFlags *genericclioptions.ConfigFlags
+	printFlags  *genericclioptions.PrintFlags
+
+	namespaceMapping map[string]string
+
+	dryRunStrategy cmdutil.DryRunStrategy
+
+	dynamicClient dynamic.Interface
+	mapper        apimeta.RESTMapper
+	printer       printers.ResourcePrinter
+
+	genericclioptions.IOStreams
+}
+
+func newRestoreRunner(ioStreams genericclioptions.IOStreams) *restoreRunner {
+	return &restoreRunner{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		printFlags:  genericclioptions.NewPrintFlags(printedOperationTypeCreated).WithTypeSetter(scheme.Scheme),
+		IOStreams:   ioStreams,
+	}
+}
+
+func NewCmdRestore(streams genericclioptions.IOStreams) *cobra.Command {
+	r := newRestoreRunner(streams)
+
+	cmd := &cobra.Command{
+		Use:     "restore BACKUP_DIR|REPORT",
+		Short:   restoreShortDescription,
+		Example: restoreExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			f := cmdutil.NewFactory(r.configFlags)
+
+			cmdutil.CheckErr(r.Complete(f, cmd))
+			cmdutil.CheckErr(r.Run(context.Background(), args[0]))
+		},
+	}
+
+	r.configFlags.AddFlags(cmd.Flags())
+	r.printFlags.AddFlags(cmd)
+
+	cmdutil.AddDryRunFlag(cmd)
+
+	cmd.Flags().StringToStringVar(&r.namespaceMapping, "namespace-mapping", nil, "Mapping from the namespaces of backed up resources to the namespaces they're restored into (e.g. --namespace-mapping old=new)")
+
+	return cmd
+}
+
+func (r *restoreRunner) Complete(f cmdutil.Factory, cmd *cobra.Command) (err error) {
+	r.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+	if err != nil {
+		return
+	}
+
+	r.printFlags = cmdutil.PrintFlagsWithDryRunStrategy(r.printFlags, r.dryRunStrategy)
+	r.printer, err = r.printFlags.ToPrinter()
+	if err != nil {
+		return
+	}
+
+	r.dynamicClient, err = f.DynamicClient()
+	if err != nil {
+		return
+	}
+	r.mapper, err = f.ToRESTMapper()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *restoreRunner) Run(ctx context.Context, path string) error {
+	objs, err := loadManifests(path)
+	if err != nil {
+		return err
+	}
+
+	sortByRestoreOrder(objs)
+
+	var (
+		errs      []error
+		conflicts int
+	)
+
+	for _, obj := range objs {
+		r.remapNamespace(obj)
+
+		created, err := r.restore(ctx, obj)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s/%s: %w", obj.GetKind(), obj.GetName(), err))
+			continue
+		}
+		if !created {
+			r.Errorf("%s/%s conflicts with an existing resource\n", strings.ToLower(obj.GetKind()), obj.GetName())
+			conflicts++
+			continue
+		}
+
+		r.printer.PrintObj(obj, r.Out)
+	}
+
+	if conflicts > 0 {
+		errs = append(errs, fmt.Errorf("%d resource(s) already exist", conflicts))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// restore creates the object. It returns false without error if the object already exists.
+func (r *restoreRunner) restore(ctx context.Context, obj *unstructured.Unstructured) (bool, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, err
+	}
+
+	var client dynamic.ResourceInterface = r.dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == apimeta.RESTScopeNameNamespace {
+		client = r.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	}
+
+	if r.dryRunStrategy == cmdutil.DryRunClient {
+		_, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		switch {
+		case err == nil:
+			return false, nil
+		case apierrors.IsNotFound(err):
+			return true, nil
+		default:
+			return false, err
+		}
+	}
+
+	opts := metav1.CreateOptions{}
+	if r.dryRunStrategy == cmdutil.DryRunServer {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	if _, err := client.Create(ctx, obj, opts); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// remapNamespace changes the namespace of the object, and the one of the claim a PersistentVolume is bound to,
+// according to the namespace mapping.
+func (r *restoreRunner) remapNamespace(obj *unstructured.Unstructured) {
+	if ns, ok := r.namespaceMapping[obj.GetNamespace()]; ok && obj.GetNamespace() != "" {
+		obj.SetNamespace(ns)
+	}
+
+	if obj.GetKind() != resource.KindPersistentVolume {
+		return
+	}
+
+	// The restored PersistentVolume should be bound to the restored PersistentVolumeClaim whose UID differs.
+	unstructured.RemoveNestedField(obj.Object, "spec", "claimRef", "uid")
+	unstructured.RemoveNestedField(obj.Object, "spec", "claimRef", "resourceVersion")
+
+	claimNamespace, ok, _ := unstructured.NestedString(obj.Object, "spec", "claimRef", "namespace")
+	if !ok {
+		return
+	}
+	if ns, ok := r.namespaceMapping[claimNamespace]; ok {
+		unstructured.SetNestedField(obj.Object, ns, "spec", "claimRef", "namespace")
+	}
+}
+
+func (r *restoreRunner) Errorf(format string, a ...interface{}) {
+	fmt.Fprintf(r.ErrOut, format, a...)
+}
+
+// loadManifests loads the manifests from a backup directory, or from the backups of the resources deleted in a report.
+func loadManifests(path string) ([]*unstructured.Unstructured, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if fi.IsDir() {
+		return loadBackupDir(path)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rep report.Report
+	if err := yaml.Unmarshal(b, &rep); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+
+	var objs []*unstructured.Unstructured
+	for _, e := range rep.Entries {
+		if e.Status != report.StatusDeleted || e.Backup == "" {
+			continue
+		}
+		obj, err := loadManifest(e.Backup)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
+
+func loadBackupDir(dir string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+
+		obj, err := loadManifest(path)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, obj)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return objs, nil
+}
+
+func loadManifest(path string) (*unstructured.Unstructured, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(j); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+
+	return obj, nil
+}
+
+// sortByRestoreOrder sorts the objects so that the ones depended on by others come first.
+func sortByRestoreOrder(objs []*unstructured.Unstructured) {
+	order := func(kind string) int {
+		for i, k := range restoreOrder {
+			if k == kind {
+				return i
+			}
+		}
+		return len(restoreOrder)
+	}
+
+	sort.SliceStable(objs, func(i, j int) bool {
+		return order(objs[i].GetKind()) < order(objs[j].GetKind())
+	})
+}
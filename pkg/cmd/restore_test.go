@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/micnncim/kubectl-reap/pkg/backup"
+)
+
+func Test_restoreRunner_Run(t *testing.T) {
+	const (
+		fakeNamespace       = "fake-ns"
+		fakeMappedNamespace = "fake-mapped-ns"
+	)
+
+	backupDir := t.TempDir()
+	for _, obj := range []runtime.Object{
+		&corev1.PersistentVolumeClaim{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace, Name: "fake-pvc"},
+		},
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace, Name: "fake-cm"},
+		},
+		&corev1.PersistentVolume{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolume"},
+			ObjectMeta: metav1.ObjectMeta{Name: "fake-pv"},
+			Spec: corev1.PersistentVolumeSpec{
+				ClaimRef: &corev1.ObjectReference{Namespace: fakeNamespace, Name: "fake-pvc", UID: "fake-uid"},
+			},
+		},
+	} {
+		if _, err := backup.Write(backupDir, obj); err != nil {
+			t.Fatalf("failed to back up: %v", err)
+		}
+	}
+
+	existingConfigMap := &unstructured.Unstructured{}
+	existingConfigMap.SetAPIVersion("v1")
+	existingConfigMap.SetKind("ConfigMap")
+	existingConfigMap.SetNamespace(fakeMappedNamespace)
+	existingConfigMap.SetName("fake-cm")
+
+	tests := []struct {
+		name           string
+		dryRunStrategy cmdutil.DryRunStrategy
+		wantOut        string
+		wantErrOut     string
+	}{
+		{
+			name:           "resources should be created in dependency order and conflicts should be reported",
+			dryRunStrategy: cmdutil.DryRunNone,
+			wantOut: "persistentvolume/fake-pv created\n" +
+				"persistentvolumeclaim/fake-pvc created\n",
+			wantErrOut: "configmap/fake-cm conflicts with an existing resource\n",
+		},
+		{
+			name:           "conflicts should be reported in client-side dry-run",
+			dryRunStrategy: cmdutil.DryRunClient,
+			wantOut: "persistentvolume/fake-pv created (dry run)\n" +
+				"persistentvolumeclaim/fake-pvc created (dry run)\n",
+			wantErrOut: "configmap/fake-cm conflicts with an existing resource\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+
+			r := newRestoreRunner(streams)
+			r.namespaceMapping = map[string]string{fakeNamespace: fakeMappedNamespace}
+			r.dryRunStrategy = tt.dryRunStrategy
+			r.dynamicClient = dynamicfake.NewSimpleDynamicClient(scheme.Scheme, existingConfigMap.DeepCopy())
+			r.mapper = testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme)
+
+			var err error
+			r.printFlags = cmdutil.PrintFlagsWithDryRunStrategy(r.printFlags, r.dryRunStrategy)
+			r.printer, err = r.printFlags.ToPrinter()
+			if err != nil {
+				t.Fatalf("failed to complete printer: %v", err)
+			}
+
+			if err := r.Run(context.Background(), backupDir); err == nil {
+				t.Errorf("restoreRunner.Run() should return error due to conflicts")
+			}
+
+			if diff := cmp.Diff(tt.wantOut, out.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantErrOut, errOut.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			if tt.dryRunStrategy != cmdutil.DryRunNone {
+				return
+			}
+
+			pv, err := r.dynamicClient.
+				Resource(corev1.SchemeGroupVersion.WithResource("persistentvolumes")).
+				Get(context.Background(), "fake-pv", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get restored PersistentVolume: %v", err)
+			}
+			claimRef, _, _ := unstructured.NestedStringMap(pv.Object, "spec", "claimRef")
+			if diff := cmp.Diff(map[string]string{"namespace": fakeMappedNamespace, "name": "fake-pvc"}, claimRef); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package determiner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+// agePolicy determines whether a resource is old enough to be reaped.
+// The zero value regards all resources as old enough.
+type agePolicy struct {
+	olderThan       time.Duration
+	olderThanByKind map[string]time.Duration // key=lower-cased kind
+}
+
+func newAgePolicy(olderThan time.Duration, olderThanByKind map[string]time.Duration) (*agePolicy, error) {
+	if olderThan < 0 {
+		return nil, fmt.Errorf("minimum age must not be negative: %s", olderThan)
+	}
+
+	p := &agePolicy{
+		olderThan:       olderThan,
+		olderThanByKind: make(map[string]time.Duration, len(olderThanByKind)),
+	}
+
+	for kind, d := range olderThanByKind {
+		if !isSupportedKind(kind) {
+			return nil, fmt.Errorf("unsupported kind: %s", kind)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("minimum age of %s must not be negative: %s", kind, d)
+		}
+		p.olderThanByKind[strings.ToLower(kind)] = d
+	}
+
+	return p, nil
+}
+
+// minAge returns the minimum age of the given kind. A per-kind age takes precedence over the global one.
+func (p *agePolicy) minAge(kind string) time.Duration {
+	if d, ok := p.olderThanByKind[strings.ToLower(kind)]; ok {
+		return d
+	}
+	return p.olderThan
+}
+
+// oldEnough returns true if the object has been idle for longer than the minimum age of its kind.
+func (p *agePolicy) oldEnough(obj runtime.Object, kind string, now time.Time) (bool, error) {
+	minAge := p.minAge(kind)
+	if minAge <= 0 {
+		return true, nil
+	}
+
+	since, err := idleSince(obj, kind)
+	if err != nil {
+		return false, err
+	}
+
+	return now.Sub(since) >= minAge, nil
+}
+
+// idleSince returns the time since when the object has been idle.
+// It's the completion time for finished Jobs, the termination time for terminated Pods,
+// and the creation time otherwise.
+func idleSince(obj runtime.Object, kind string) (time.Time, error) {
+	switch kind {
+	case resource.KindPod:
+		pod, err := resource.ObjectToPod(obj)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t := podTerminationTime(pod); !t.IsZero() {
+			return t, nil
+		}
+		return pod.CreationTimestamp.Time, nil
+
+	case resource.KindJob:
+		job, err := resource.ObjectToJob(obj)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if c := jobFinishedCondition(job); c != nil {
+			return c.LastTransitionTime.Time, nil
+		}
+		return job.CreationTimestamp.Time, nil
+
+	default:
+		accessor, err := apimeta.Accessor(obj)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return accessor.GetCreationTimestamp().Time, nil
+	}
+}
+
+// podTerminationTime returns the time when the last container of the Pod terminated.
+// It returns zero time if no container has terminated.
+func podTerminationTime(pod *corev1.Pod) time.Time {
+	var t time.Time
+
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Terminated != nil && status.State.Terminated.FinishedAt.After(t) {
+				t = status.State.Terminated.FinishedAt.Time
+			}
+		}
+	}
+
+	return t
+}
+
+func isSupportedKind(kind string) bool {
+	for _, k := range SupportedKinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
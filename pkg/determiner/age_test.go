@@ -0,0 +1,188 @@
+package determiner
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_agePolicy_oldEnough(t *testing.T) {
+	fakeNow := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type fields struct {
+		olderThan       time.Duration
+		olderThanByKind map[string]time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		obj     runtime.Object
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "ConfigMap should be old enough without minimum age",
+			fields: fields{},
+			obj: &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: resource.KindConfigMap},
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(fakeNow.Add(-time.Second)),
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "ConfigMap should not be old enough when it was created recently",
+			fields: fields{
+				olderThan: time.Hour,
+			},
+			obj: &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: resource.KindConfigMap},
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(fakeNow.Add(-5 * time.Second)),
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "per-kind minimum age should take precedence over global one",
+			fields: fields{
+				olderThan:       time.Hour,
+				olderThanByKind: map[string]time.Duration{"configmap": time.Minute},
+			},
+			obj: &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: resource.KindConfigMap},
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(fakeNow.Add(-10 * time.Minute)),
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Job should not be old enough when it completed recently",
+			fields: fields{
+				olderThan: time.Hour,
+			},
+			obj: &batchv1.Job{
+				TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: resource.KindJob},
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(fakeNow.Add(-24 * time.Hour)),
+				},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(fakeNow.Add(-time.Minute))},
+					},
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Pod should be old enough when its containers terminated long ago",
+			fields: fields{
+				olderThan: time.Hour,
+			},
+			obj: &corev1.Pod{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: resource.KindPod},
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(fakeNow.Add(-3 * time.Hour)),
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(fakeNow.Add(-2 * time.Hour))}}},
+					},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Pod should not be old enough when its container terminated recently",
+			fields: fields{
+				olderThan: time.Hour,
+			},
+			obj: &corev1.Pod{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: resource.KindPod},
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(fakeNow.Add(-3 * time.Hour)),
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(fakeNow.Add(-2 * time.Hour))}}},
+						{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(fakeNow.Add(-time.Minute))}}},
+					},
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := newAgePolicy(tt.fields.olderThan, tt.fields.olderThanByKind)
+			if err != nil {
+				t.Fatalf("failed to construct age policy: %v", err)
+			}
+
+			got, err := p.oldEnough(tt.obj, tt.obj.GetObjectKind().GroupVersionKind().Kind, fakeNow)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("agePolicy.oldEnough() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("agePolicy.oldEnough() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newAgePolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		olderThan       time.Duration
+		olderThanByKind map[string]time.Duration
+		wantErr         bool
+	}{
+		{
+			name:            "valid minimum ages should be accepted",
+			olderThan:       time.Hour,
+			olderThanByKind: map[string]time.Duration{"Job": 24 * time.Hour},
+			wantErr:         false,
+		},
+		{
+			name:      "negative minimum age should be rejected",
+			olderThan: -time.Hour,
+			wantErr:   true,
+		},
+		{
+			name:            "unsupported kind should be rejected",
+			olderThanByKind: map[string]time.Duration{"Deployment": time.Hour},
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := newAgePolicy(tt.olderThan, tt.olderThanByKind); (err != nil) != tt.wantErr {
+				t.Errorf("newAgePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package determiner
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Decision is the result of determining whether a resource should be deleted.
+type Decision struct {
+	// Delete is true if the resource should be deleted.
+	Delete bool
+	// Reason describes why the resource should or should not be deleted.
+	Reason string
+	// References are the objects the decision depends on, e.g. Pods mounting a ConfigMap.
+	References []Reference
+}
+
+// Reference identifies an object involved in a Decision.
+type Reference struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (r Reference) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+func (d *Decision) String() string {
+	verdict := "keep"
+	if d.Delete {
+		verdict = "delete"
+	}
+
+	if len(d.References) == 0 {
+		return fmt.Sprintf("%s (%s)", verdict, d.Reason)
+	}
+
+	refs := make([]string, 0, len(d.References))
+	for _, ref := range d.References {
+		refs = append(refs, ref.String())
+	}
+
+	return fmt.Sprintf("%s (%s: %s)", verdict, d.Reason, strings.Join(refs, ", "))
+}
+
+func deleteDecision(format string, a ...interface{}) *Decision {
+	return &Decision{
+		Delete: true,
+		Reason: fmt.Sprintf(format, a...),
+	}
+}
+
+func keepDecision(refs []Reference, format string, a ...interface{}) *Decision {
+	return &Decision{
+		Delete:     false,
+		Reason:     fmt.Sprintf(format, a...),
+		References: refs,
+	}
+}
+
+// references maps objects to the objects referencing them.
+type references map[types.NamespacedName][]Reference
+
+func (r references) add(target types.NamespacedName, by Reference) {
+	r[target] = append(r[target], by)
+}
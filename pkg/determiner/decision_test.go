@@ -0,0 +1,40 @@
+package determiner
+
+import (
+	"testing"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func TestDecision_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision *Decision
+		want     string
+	}{
+		{
+			name:     "decision to delete should be described with its reason",
+			decision: deleteDecision("not referenced by any Pods or workloads"),
+			want:     "delete (not referenced by any Pods or workloads)",
+		},
+		{
+			name: "decision to keep should be described with its references",
+			decision: keepDecision([]Reference{
+				{Kind: resource.KindPod, Namespace: "fake-ns", Name: "fake-pod"},
+				{Kind: resource.KindStorageClass, Name: "fake-sc"},
+			}, "referenced by other resources"),
+			want: "keep (referenced by other resources: Pod/fake-ns/fake-pod, StorageClass/fake-sc)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.decision.String(); got != tt.want {
+				t.Errorf("Decision.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
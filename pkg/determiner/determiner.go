@@ -3,12 +3,18 @@ package determiner
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
-	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	cliresource "k8s.io/cli-runtime/pkg/resource"
 
 	"github.com/micnncim/kubectl-reap/pkg/resource"
@@ -17,28 +23,105 @@ import (
 var checkVolumeSatisfyClaimFunc = resource.CheckVolumeSatisfyClaim
 
 type Determiner interface {
-	DetermineDeletion(ctx context.Context, info *cliresource.Info) (bool, error)
+	DetermineDeletion(ctx context.Context, info *cliresource.Info) (*Decision, error)
 }
 
 // determiner determines whether a resource should be deleted.
 type determiner struct {
 	resourceClient resource.Client
 
-	usedConfigMaps             map[string]struct{} // key=ConfigMap.Name
-	usedSecrets                map[string]struct{} // key=Secret.Name
-	usedPersistentVolumeClaims map[string]struct{} // key=PersistentVolumeClaim.Name
+	podPolicy                         podPolicy
+	agePolicy                         agePolicy
+	persistentVolumeCapacityHeuristic bool
+	serviceEmptyFor                   time.Duration
+	clock                             func() time.Time
+
+	usedConfigMaps             references                        // key=ConfigMap.Namespace/ConfigMap.Name
+	usedSecrets                references                        // key=Secret.Namespace/Secret.Name
+	usedPersistentVolumeClaims references                        // key=PersistentVolumeClaim.Namespace/PersistentVolumeClaim.Name
+	usedServiceAccounts        references                        // key=ServiceAccount.Namespace/ServiceAccount.Name
+	usedRoles                  references                        // key=Role.Namespace/Role.Name
+	usedClusterRoles           references                        // key=ClusterRole.Name
+	preservedJobs              map[types.NamespacedName]struct{} // key=Job.Namespace/Job.Name
 
 	pods                   []*corev1.Pod
 	replicaSets            []*appsv1.ReplicaSet
+	deployments            []*appsv1.Deployment
+	statefulSets           []*appsv1.StatefulSet
+	daemonSets             []*appsv1.DaemonSet
+	jobs                   []*batchv1.Job
+	cronJobs               []*batchv1beta1.CronJob
 	persistentVolumeClaims []*corev1.PersistentVolumeClaim
+	endpointSlices         []*resource.EndpointSlice
+	serviceAccounts        []*corev1.ServiceAccount
+	roles                  []*rbacv1.Role
+	clusterRoles           []*rbacv1.ClusterRole
+	roleBindings           []*rbacv1.RoleBinding
+	clusterRoleBindings    []*rbacv1.ClusterRoleBinding
 }
 
 // Guarantee *determiner implements Determiner.
 var _ Determiner = (*determiner)(nil)
 
-func New(resourceClient resource.Client, r *cliresource.Result, namespace string) (Determiner, error) {
+// Option configures a Determiner.
+type Option func(*determiner) error
+
+// WithPodPolicy sets the Pod phases to be reaped and the minimum age of Pending Pods to be reaped.
+func WithPodPolicy(phases []string, pendingOlderThan time.Duration) Option {
+	return func(d *determiner) error {
+		p, err := newPodPolicy(phases, pendingOlderThan)
+		if err != nil {
+			return err
+		}
+		d.podPolicy = *p
+		return nil
+	}
+}
+
+// WithMinAge sets the minimum age of resources to be reaped.
+// olderThanByKind overrides olderThan for each kind, and zero means no minimum age.
+func WithMinAge(olderThan time.Duration, olderThanByKind map[string]time.Duration) Option {
+	return func(d *determiner) error {
+		p, err := newAgePolicy(olderThan, olderThanByKind)
+		if err != nil {
+			return err
+		}
+		d.agePolicy = *p
+		return nil
+	}
+}
+
+// WithPersistentVolumeCapacityHeuristic makes Available PersistentVolumes without claimRef be reaped
+// when they can't satisfy any unbound PersistentVolumeClaims by capacity, StorageClass, VolumeMode and AccessModes.
+func WithPersistentVolumeCapacityHeuristic(enabled bool) Option {
+	return func(d *determiner) error {
+		d.persistentVolumeCapacityHeuristic = enabled
+		return nil
+	}
+}
+
+// WithServiceEmptyFor sets the minimum period for which the EndpointSlices of Services to be reaped have been empty.
+func WithServiceEmptyFor(period time.Duration) Option {
+	return func(d *determiner) error {
+		if period < 0 {
+			return fmt.Errorf("period for which EndpointSlices have been empty must not be negative: %s", period)
+		}
+		d.serviceEmptyFor = period
+		return nil
+	}
+}
+
+func New(resourceClient resource.Client, r *cliresource.Result, namespace string, opts ...Option) (Determiner, error) {
 	d := &determiner{
-		resourceClient: resourceClient,
+		resourceClient:  resourceClient,
+		serviceEmptyFor: DefaultServiceEmptyFor,
+		clock:           time.Now,
+	}
+
+	for _, opt := range opts {
+		if err := opt(d); err != nil {
+			return nil, err
+		}
 	}
 
 	var (
@@ -46,7 +129,15 @@ func New(resourceClient resource.Client, r *cliresource.Result, namespace string
 		reapSecrets                bool
 		reapPersistentVolumes      bool
 		reapPersistentVolumeClaims bool
+		reapJobs                   bool
 		reapPodDisruptionBudgets   bool
+		reapServices               bool
+		reapServiceAccounts        bool
+		reapReplicaSets            bool
+		reapRoles                  bool
+		reapClusterRoles           bool
+		reapRoleBindings           bool
+		reapClusterRoleBindings    bool
 	)
 
 	if err := r.Visit(func(info *cliresource.Info, err error) error {
@@ -59,8 +150,24 @@ func New(resourceClient resource.Client, r *cliresource.Result, namespace string
 			reapPersistentVolumes = true
 		case resource.KindPersistentVolumeClaim:
 			reapPersistentVolumeClaims = true
+		case resource.KindJob:
+			reapJobs = true
 		case resource.KindPodDisruptionBudget:
 			reapPodDisruptionBudgets = true
+		case resource.KindService:
+			reapServices = true
+		case resource.KindServiceAccount:
+			reapServiceAccounts = true
+		case resource.KindReplicaSet:
+			reapReplicaSets = true
+		case resource.KindRole:
+			reapRoles = true
+		case resource.KindClusterRole:
+			reapClusterRoles = true
+		case resource.KindRoleBinding:
+			reapRoleBindings = true
+		case resource.KindClusterRoleBinding:
+			reapClusterRoleBindings = true
 		}
 		return nil
 	}); err != nil {
@@ -69,7 +176,7 @@ func New(resourceClient resource.Client, r *cliresource.Result, namespace string
 
 	ctx := context.Background()
 
-	if reapConfigMaps || reapSecrets || reapPersistentVolumeClaims || reapPodDisruptionBudgets {
+	if reapConfigMaps || reapSecrets || reapPersistentVolumeClaims || reapPodDisruptionBudgets || reapServices || reapServiceAccounts {
 		var err error
 		d.pods, err = d.resourceClient.ListPods(ctx, namespace)
 		if err != nil {
@@ -77,17 +184,97 @@ func New(resourceClient resource.Client, r *cliresource.Result, namespace string
 		}
 	}
 
-	if reapConfigMaps || reapSecrets {
+	needWorkloads := reapConfigMaps || reapSecrets || reapPersistentVolumeClaims || reapServiceAccounts
+
+	switch {
+	case needWorkloads:
+		if err := d.listWorkloads(ctx, namespace); err != nil {
+			return nil, err
+		}
+	case reapJobs:
+		var err error
+		d.jobs, err = d.resourceClient.ListJobs(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		d.cronJobs, err = d.resourceClient.ListCronJobs(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reapReplicaSets && !needWorkloads {
 		var err error
 		d.replicaSets, err = d.resourceClient.ListReplicaSets(ctx, namespace)
 		if err != nil {
 			return nil, err
 		}
+		d.deployments, err = d.resourceClient.ListDeployments(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if reapPersistentVolumes {
 		var err error
-		d.persistentVolumeClaims, err = d.resourceClient.ListPersistentVolumeClaims(ctx, namespace)
+		// PVs are cluster-scoped, so PVCs in all namespaces can be bound to them.
+		d.persistentVolumeClaims, err = d.resourceClient.ListPersistentVolumeClaims(ctx, metav1.NamespaceAll)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reapServices {
+		var err error
+		d.endpointSlices, err = d.resourceClient.ListEndpointSlices(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reapServiceAccounts || reapRoles || reapClusterRoles {
+		// RoleBindings in any namespace can bind ServiceAccounts in the namespace and ClusterRoles,
+		// while Roles can be bound only by RoleBindings in their namespace.
+		rbNamespace := namespace
+		if reapServiceAccounts || reapClusterRoles {
+			rbNamespace = metav1.NamespaceAll
+		}
+
+		var err error
+		d.roleBindings, err = d.resourceClient.ListRoleBindings(ctx, rbNamespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reapServiceAccounts || reapClusterRoles {
+		var err error
+		d.clusterRoleBindings, err = d.resourceClient.ListClusterRoleBindings(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reapRoleBindings {
+		var err error
+		d.roles, err = d.resourceClient.ListRoles(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reapRoleBindings || reapClusterRoleBindings || reapClusterRoles {
+		var err error
+		d.clusterRoles, err = d.resourceClient.ListClusterRoles(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reapRoleBindings || reapClusterRoleBindings {
+		var err error
+		// Bindings can bind ServiceAccounts in any namespace.
+		d.serviceAccounts, err = d.resourceClient.ListServiceAccounts(ctx, metav1.NamespaceAll)
 		if err != nil {
 			return nil, err
 		}
@@ -98,26 +285,117 @@ func New(resourceClient resource.Client, r *cliresource.Result, namespace string
 	}
 
 	if reapSecrets {
-		sas, err := d.resourceClient.ListServiceAccounts(ctx, namespace)
+		var err error
+		d.usedSecrets, err = d.detectUsedSecrets(ctx, namespace)
 		if err != nil {
 			return nil, err
 		}
-		d.usedSecrets = d.detectUsedSecrets(sas)
 	}
 
 	if reapPersistentVolumeClaims {
 		d.usedPersistentVolumeClaims = d.detectUsedPersistentVolumeClaims()
 	}
 
+	if reapServiceAccounts {
+		d.usedServiceAccounts = d.detectUsedServiceAccounts()
+	}
+
+	if reapRoles {
+		d.usedRoles = d.detectUsedRoles()
+	}
+
+	if reapClusterRoles {
+		d.usedClusterRoles = d.detectUsedClusterRoles()
+	}
+
+	if reapJobs {
+		d.preservedJobs = d.detectPreservedJobs()
+	}
+
 	return d, nil
 }
 
+// listWorkloads lists the workloads whose pod templates can reference other resources.
+func (d *determiner) listWorkloads(ctx context.Context, namespace string) (err error) {
+	d.replicaSets, err = d.resourceClient.ListReplicaSets(ctx, namespace)
+	if err != nil {
+		return
+	}
+
+	d.deployments, err = d.resourceClient.ListDeployments(ctx, namespace)
+	if err != nil {
+		return
+	}
+
+	d.statefulSets, err = d.resourceClient.ListStatefulSets(ctx, namespace)
+	if err != nil {
+		return
+	}
+
+	d.daemonSets, err = d.resourceClient.ListDaemonSets(ctx, namespace)
+	if err != nil {
+		return
+	}
+
+	d.jobs, err = d.resourceClient.ListJobs(ctx, namespace)
+	if err != nil {
+		return
+	}
+
+	d.cronJobs, err = d.resourceClient.ListCronJobs(ctx, namespace)
+	return
+}
+
 // DetermineDeletion determines whether a resource should be deleted.
-func (d *determiner) DetermineDeletion(ctx context.Context, info *cliresource.Info) (bool, error) {
+// Resources younger than the minimum age of their kind are never deleted.
+func (d *determiner) DetermineDeletion(ctx context.Context, info *cliresource.Info) (*Decision, error) {
+	decision, err := d.determineDeletion(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	if !decision.Delete {
+		return decision, nil
+	}
+
+	kind := info.Object.GetObjectKind().GroupVersionKind().Kind
+	ok, err := d.agePolicy.oldEnough(info.Object, kind, d.now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return keepDecision(nil, "younger than %s although %s", d.agePolicy.minAge(kind), decision.Reason), nil
+	}
+
+	return decision, nil
+}
+
+// SupportedKinds is the list of kinds which can be reaped, i.e. the ones determineDeletion supports.
+var SupportedKinds = []string{
+	resource.KindPod,
+	resource.KindReplicaSet,
+	resource.KindConfigMap,
+	resource.KindSecret,
+	resource.KindPersistentVolume,
+	resource.KindPersistentVolumeClaim,
+	resource.KindJob,
+	resource.KindPodDisruptionBudget,
+	resource.KindHorizontalPodAutoscaler,
+	resource.KindService,
+	resource.KindServiceAccount,
+	resource.KindRole,
+	resource.KindClusterRole,
+	resource.KindRoleBinding,
+	resource.KindClusterRoleBinding,
+}
+
+func (d *determiner) determineDeletion(ctx context.Context, info *cliresource.Info) (*Decision, error) {
 	switch kind := info.Object.GetObjectKind().GroupVersionKind().Kind; kind {
 	case resource.KindPod:
 		return d.determineDeletionPod(info)
 
+	case resource.KindReplicaSet:
+		return d.determineDeletionReplicaSet(info)
+
 	case resource.KindConfigMap:
 		return d.determineDeletionConfigMap(info)
 
@@ -139,258 +417,365 @@ func (d *determiner) DetermineDeletion(ctx context.Context, info *cliresource.In
 	case resource.KindHorizontalPodAutoscaler:
 		return d.determineDeletionHorizontalPodAutoscaler(ctx, info)
 
+	case resource.KindService:
+		return d.determineDeletionService(info)
+
+	case resource.KindServiceAccount:
+		return d.determineDeletionServiceAccount(info)
+
+	case resource.KindRole:
+		return d.determineDeletionRole(info)
+
+	case resource.KindClusterRole:
+		return d.determineDeletionClusterRole(info)
+
+	case resource.KindRoleBinding:
+		return d.determineDeletionRoleBinding(info)
+
+	case resource.KindClusterRoleBinding:
+		return d.determineDeletionClusterRoleBinding(info)
+
 	default:
-		return false, fmt.Errorf("unsupported kind: %s/%s", kind, info.Name)
+		return nil, fmt.Errorf("unsupported kind: %s/%s", kind, info.Name)
 	}
 }
 
-func (d *determiner) determineDeletionPod(info *cliresource.Info) (bool, error) {
+func (d *determiner) determineDeletionPod(info *cliresource.Info) (*Decision, error) {
 	pod, err := resource.ObjectToPod(info.Object)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	phase := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		phase = fmt.Sprintf("%s (%s)", phase, pod.Status.Reason)
 	}
 
-	return pod.Status.Phase != corev1.PodRunning, nil
+	if !d.podPolicy.shouldReap(pod, d.now()) {
+		return keepDecision(nil, "phase %s is not selected", phase), nil
+	}
+	return deleteDecision("phase is %s", phase), nil
 }
 
-func (d *determiner) determineDeletionConfigMap(info *cliresource.Info) (bool, error) {
-	_, ok := d.usedConfigMaps[info.Name]
-	return !ok, nil
+func (d *determiner) determineDeletionConfigMap(info *cliresource.Info) (*Decision, error) {
+	if refs, ok := d.usedConfigMaps[types.NamespacedName{Namespace: info.Namespace, Name: info.Name}]; ok {
+		return keepDecision(refs, "referenced by Pods or workloads"), nil
+	}
+	return deleteDecision("not referenced by any Pods or workloads"), nil
 }
 
-func (d *determiner) determineDeletionSecret(info *cliresource.Info) (bool, error) {
-	_, ok := d.usedSecrets[info.Name]
-	return !ok, nil
+func (d *determiner) determineDeletionSecret(info *cliresource.Info) (*Decision, error) {
+	if refs, ok := d.usedSecrets[types.NamespacedName{Namespace: info.Namespace, Name: info.Name}]; ok {
+		return keepDecision(refs, "referenced by other resources"), nil
+	}
+	return deleteDecision("not referenced by any Pods, workloads, ServiceAccounts, Ingresses, StorageClasses, or PersistentVolumes"), nil
 }
 
-func (d *determiner) determineDeletionPersistentVolume(info *cliresource.Info) (bool, error) {
+func (d *determiner) determineDeletionPersistentVolume(info *cliresource.Info) (*Decision, error) {
 	volume, err := resource.ObjectToPersistentVolume(info.Object)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	switch volume.Status.Phase {
+	case corev1.VolumeReleased:
+		// Released volumes with Delete or Recycle policy are reclaimed by the controller.
+		if volume.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimRetain {
+			return deleteDecision("Released with %s reclaim policy", volume.Spec.PersistentVolumeReclaimPolicy), nil
+		}
+		return keepDecision(nil, "Released, to be reclaimed with %s reclaim policy", volume.Spec.PersistentVolumeReclaimPolicy), nil
+
+	case corev1.VolumeFailed:
+		return deleteDecision("automatic reclamation Failed"), nil
+
+	case corev1.VolumeBound:
+		return d.determineDeletionPersistentVolumeClaimRef(volume.Spec.ClaimRef, "bound"), nil
+
+	case corev1.VolumeAvailable:
+		if ref := volume.Spec.ClaimRef; ref != nil {
+			// The claim of a pre-bound volume is commonly created after the volume.
+			if !d.persistentVolumeCapacityHeuristic && !d.claimRefExists(ref) {
+				return keepDecision(nil, "Available, pre-bound to PersistentVolumeClaim %s/%s yet to be created", ref.Namespace, ref.Name), nil
+			}
+			return d.determineDeletionPersistentVolumeClaimRef(ref, "pre-bound"), nil
+		}
+		if !d.persistentVolumeCapacityHeuristic {
+			return keepDecision(nil, "Available"), nil
+		}
+		if claim := d.satisfiedUnboundClaim(volume); claim != nil {
+			return keepDecision([]Reference{referenceTo(resource.KindPersistentVolumeClaim, claim)}, "Available for unbound PersistentVolumeClaims"), nil
+		}
+		return deleteDecision("Available but can't satisfy any unbound PersistentVolumeClaims"), nil
+
+	default:
+		return keepDecision(nil, "phase is %s", volume.Status.Phase), nil
+	}
+}
+
+func (d *determiner) determineDeletionPersistentVolumeClaimRef(ref *corev1.ObjectReference, state string) *Decision {
+	if !d.claimRefExists(ref) {
+		if ref == nil {
+			return deleteDecision("%s to no PersistentVolumeClaim", state)
+		}
+		return deleteDecision("%s to missing PersistentVolumeClaim %s/%s", state, ref.Namespace, ref.Name)
+	}
+
+	return keepDecision([]Reference{{
+		Kind:      resource.KindPersistentVolumeClaim,
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+	}}, "%s to existing PersistentVolumeClaim", state)
+}
+
+// claimRefExists returns true if the PersistentVolumeClaim the PersistentVolume's claimRef points to exists.
+// A claim with the same name but a different UID is regarded as a different claim.
+func (d *determiner) claimRefExists(ref *corev1.ObjectReference) bool {
+	if ref == nil {
+		return false
 	}
 
 	for _, claim := range d.persistentVolumeClaims {
+		if claim.Namespace != ref.Namespace || claim.Name != ref.Name {
+			continue
+		}
+		return ref.UID == "" || claim.UID == ref.UID
+	}
+
+	return false
+}
+
+// satisfiedUnboundClaim returns a PersistentVolumeClaim which has not been bound yet
+// and the PersistentVolume can be bound to. It returns nil if there is no such claim.
+func (d *determiner) satisfiedUnboundClaim(volume *corev1.PersistentVolume) *corev1.PersistentVolumeClaim {
+	for _, claim := range d.persistentVolumeClaims {
+		if claim.Spec.VolumeName != "" && claim.Spec.VolumeName != volume.Name {
+			continue // PVC bound or pre-bound to another PV
+		}
+
+		if claim.Spec.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(claim.Spec.Selector)
+			if err != nil || !selector.Matches(labels.Set(volume.Labels)) {
+				continue
+			}
+		}
+
 		if ok := checkVolumeSatisfyClaimFunc(volume, claim); ok {
-			return false, nil
+			return claim
 		}
 	}
-	return true, nil // should delete PV if it doesn't satisfy any PVCs
+
+	return nil
 }
 
-func (d *determiner) determineDeletionPersistentVolumeClaim(info *cliresource.Info) (bool, error) {
-	_, ok := d.usedPersistentVolumeClaims[info.Name]
-	return !ok, nil
+func (d *determiner) determineDeletionPersistentVolumeClaim(info *cliresource.Info) (*Decision, error) {
+	if refs, ok := d.usedPersistentVolumeClaims[types.NamespacedName{Namespace: info.Namespace, Name: info.Name}]; ok {
+		return keepDecision(refs, "referenced by Pods or workloads"), nil
+	}
+
+	for _, sts := range d.statefulSets {
+		if sts.Namespace == info.Namespace && isStatefulSetClaim(sts, info.Name) {
+			return keepDecision([]Reference{referenceTo(resource.KindStatefulSet, sts)}, "created from volumeClaimTemplates of StatefulSet"), nil
+		}
+	}
+
+	return deleteDecision("not referenced by any Pods or workloads"), nil
 }
 
-func (d *determiner) determineDeletionJob(info *cliresource.Info) (bool, error) {
+func (d *determiner) determineDeletionJob(info *cliresource.Info) (*Decision, error) {
 	job, err := resource.ObjectToJob(info.Object)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	return job.Status.CompletionTime != nil, nil
+	finished := jobFinishedCondition(job)
+	if finished == nil {
+		return keepDecision(nil, "not finished"), nil // should not delete running Jobs
+	}
+
+	if !jobTTLExpired(job, finished, d.now()) {
+		return keepDecision(nil, "ttlSecondsAfterFinished not expired"), nil // should leave Jobs to TTL controller
+	}
+
+	if _, ok := d.preservedJobs[types.NamespacedName{Namespace: info.Namespace, Name: info.Name}]; ok {
+		var refs []Reference
+		if owner := metav1.GetControllerOf(job); owner != nil {
+			refs = append(refs, Reference{Kind: owner.Kind, Namespace: job.Namespace, Name: owner.Name})
+		}
+		return keepDecision(refs, "within history limits of CronJob"), nil // should keep Jobs within CronJob's history limits
+	}
+
+	return deleteDecision("%s", finished.Type), nil
 }
 
-func (d *determiner) determineDeletionPodDisruptionBudget(info *cliresource.Info) (bool, error) {
+func (d *determiner) determineDeletionPodDisruptionBudget(info *cliresource.Info) (*Decision, error) {
 	pdb, err := resource.ObjectToPodDisruptionBudget(info.Object)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	used, err := d.determineUsedPodDisruptionBudget(pdb)
+	pods, err := d.determineUsedPodDisruptionBudget(pdb)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	// The policy only changes which of the selected Pods can be evicted, so it's shown but doesn't affect the decision.
+	var policy string
+	if p := pdb.UnhealthyPodEvictionPolicy(); p != "" {
+		policy = fmt.Sprintf(" with unhealthyPodEvictionPolicy %s", p)
 	}
-	return !used, nil
+
+	if len(pods) > 0 {
+		return keepDecision(pods, "selecting Pods%s", policy), nil
+	}
+	return deleteDecision("not selecting any Pods%s", policy), nil
 }
 
-func (d *determiner) determineDeletionHorizontalPodAutoscaler(ctx context.Context, info *cliresource.Info) (bool, error) {
+func (d *determiner) determineDeletionHorizontalPodAutoscaler(ctx context.Context, info *cliresource.Info) (*Decision, error) {
 	hpa, err := resource.ObjectToHorizontalPodAutoscaler(info.Object)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
+	// Look up the target object itself rather than its /scale subresource
+	// so that targets without the subresource are also regarded as existing.
 	ref := hpa.Spec.ScaleTargetRef
 	u, err := d.resourceClient.GetUnstructured(ctx, ref.APIVersion, ref.Kind, ref.Name, info.Namespace)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	return u == nil, nil // should delete HPA if ScaleTargetRef's target object is not found
+	if u == nil {
+		// should delete HPA if ScaleTargetRef's target object is not found
+		return deleteDecision("scale target %s/%s not found", ref.Kind, ref.Name), nil
+	}
+	return keepDecision([]Reference{{Kind: ref.Kind, Namespace: info.Namespace, Name: ref.Name}}, "scale target exists"), nil
 }
 
-func (d *determiner) detectUsedConfigMaps() map[string]struct{} {
-	usedConfigMaps := make(map[string]struct{})
-
-	// Add Secrets used by Pods
-	for _, pod := range d.pods {
-		for _, container := range pod.Spec.Containers {
-			for _, envFrom := range container.EnvFrom {
-				if envFrom.ConfigMapRef != nil {
-					usedConfigMaps[envFrom.ConfigMapRef.Name] = struct{}{}
-				}
-			}
+func (d *determiner) now() time.Time {
+	if d.clock == nil {
+		return time.Now()
+	}
+	return d.clock()
+}
 
-			for _, env := range container.Env {
-				if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
-					usedConfigMaps[env.ValueFrom.ConfigMapKeyRef.Name] = struct{}{}
-				}
-			}
-		}
+// podSpecOwner is an object which has a PodSpec, e.g. a Pod or a workload with a pod template.
+type podSpecOwner struct {
+	ref  Reference
+	spec *corev1.PodSpec
+}
 
-		for _, volume := range pod.Spec.Volumes {
-			if volume.ConfigMap != nil {
-				usedConfigMaps[volume.ConfigMap.Name] = struct{}{}
-			}
+// podSpecOwners returns all the known objects which have a PodSpec.
+func (d *determiner) podSpecOwners() []podSpecOwner {
+	owners := make([]podSpecOwner, 0, len(d.pods)+len(d.replicaSets)+len(d.deployments)+
+		len(d.statefulSets)+len(d.daemonSets)+len(d.jobs)+len(d.cronJobs))
 
-			if volume.Projected != nil {
-				for _, source := range volume.Projected.Sources {
-					if source.ConfigMap != nil {
-						usedConfigMaps[source.ConfigMap.Name] = struct{}{}
-					}
-				}
-			}
-		}
+	for _, pod := range d.pods {
+		owners = append(owners, podSpecOwner{ref: referenceTo(resource.KindPod, pod), spec: &pod.Spec})
 	}
 
-	// Add Secrets used by ReplicaSets
 	for _, rs := range d.replicaSets {
-		for _, container := range rs.Spec.Template.Spec.Containers {
-			for _, envFrom := range container.EnvFrom {
-				if envFrom.ConfigMapRef != nil {
-					usedConfigMaps[envFrom.ConfigMapRef.Name] = struct{}{}
-				}
-			}
-
-			for _, env := range container.Env {
-				if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
-					usedConfigMaps[env.ValueFrom.ConfigMapKeyRef.Name] = struct{}{}
-				}
-			}
-		}
-
-		for _, volume := range rs.Spec.Template.Spec.Volumes {
-			if volume.ConfigMap != nil {
-				usedConfigMaps[volume.ConfigMap.Name] = struct{}{}
-			}
+		owners = append(owners, podSpecOwner{ref: referenceTo(resource.KindReplicaSet, rs), spec: &rs.Spec.Template.Spec})
+	}
 
-			if volume.Projected != nil {
-				for _, source := range volume.Projected.Sources {
-					if source.ConfigMap != nil {
-						usedConfigMaps[source.ConfigMap.Name] = struct{}{}
-					}
-				}
-			}
-		}
+	for _, deploy := range d.deployments {
+		owners = append(owners, podSpecOwner{ref: referenceTo(resource.KindDeployment, deploy), spec: &deploy.Spec.Template.Spec})
 	}
 
-	return usedConfigMaps
-}
+	for _, sts := range d.statefulSets {
+		owners = append(owners, podSpecOwner{ref: referenceTo(resource.KindStatefulSet, sts), spec: &sts.Spec.Template.Spec})
+	}
 
-func (d *determiner) detectUsedSecrets(sas []*corev1.ServiceAccount) map[string]struct{} {
-	usedSecrets := make(map[string]struct{})
+	for _, ds := range d.daemonSets {
+		owners = append(owners, podSpecOwner{ref: referenceTo(resource.KindDaemonSet, ds), spec: &ds.Spec.Template.Spec})
+	}
 
-	// Add Secrets used by Pods
-	for _, pod := range d.pods {
-		for _, imagePullSecret := range pod.Spec.ImagePullSecrets {
-			usedSecrets[imagePullSecret.Name] = struct{}{}
-		}
+	for _, job := range d.jobs {
+		owners = append(owners, podSpecOwner{ref: referenceTo(resource.KindJob, job), spec: &job.Spec.Template.Spec})
+	}
 
-		for _, container := range pod.Spec.Containers {
-			for _, envFrom := range container.EnvFrom {
-				if envFrom.SecretRef != nil {
-					usedSecrets[envFrom.SecretRef.Name] = struct{}{}
-				}
-			}
+	for _, cj := range d.cronJobs {
+		owners = append(owners, podSpecOwner{ref: referenceTo(resource.KindCronJob, cj), spec: &cj.Spec.JobTemplate.Spec.Template.Spec})
+	}
 
-			for _, env := range container.Env {
-				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
-					usedSecrets[env.ValueFrom.SecretKeyRef.Name] = struct{}{}
-				}
-			}
-		}
+	return owners
+}
 
-		for _, volume := range pod.Spec.Volumes {
-			if volume.Secret != nil {
-				usedSecrets[volume.Secret.SecretName] = struct{}{}
-			}
+func (d *determiner) detectUsedConfigMaps() references {
+	usedConfigMaps := make(references)
 
-			if volume.Projected != nil {
-				for _, source := range volume.Projected.Sources {
-					if source.Secret != nil {
-						usedSecrets[source.Secret.Name] = struct{}{}
-					}
-				}
-			}
+	// Add ConfigMaps used by Pods and pod templates
+	for _, owner := range d.podSpecOwners() {
+		for _, name := range extractPodSpecReferences(owner.spec).configMaps {
+			usedConfigMaps.add(types.NamespacedName{Namespace: owner.ref.Namespace, Name: name}, owner.ref)
 		}
 	}
 
-	// Add Secrets used by ReplicaSets
-	for _, rs := range d.replicaSets {
-		for _, container := range rs.Spec.Template.Spec.Containers {
-			for _, envFrom := range container.EnvFrom {
-				if envFrom.SecretRef != nil {
-					usedSecrets[envFrom.SecretRef.Name] = struct{}{}
-				}
-			}
-
-			for _, env := range container.Env {
-				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
-					usedSecrets[env.ValueFrom.SecretKeyRef.Name] = struct{}{}
-				}
-			}
-		}
+	return usedConfigMaps
+}
 
-		for _, volume := range rs.Spec.Template.Spec.Volumes {
-			if volume.Secret != nil {
-				usedSecrets[volume.Secret.SecretName] = struct{}{}
-			}
+func (d *determiner) detectUsedSecrets(ctx context.Context, namespace string) (references, error) {
+	usedSecrets := make(references)
 
-			if volume.Projected != nil {
-				for _, source := range volume.Projected.Sources {
-					if source.Secret != nil {
-						usedSecrets[source.Secret.Name] = struct{}{}
-					}
-				}
-			}
-		}
-	}
-
-	// Add Secrets used by ServiceAccounts
-	for _, sa := range sas {
-		for _, secret := range sa.Secrets {
-			usedSecrets[secret.Name] = struct{}{}
+	for _, consumer := range secretConsumers {
+		if err := consumer(ctx, d, namespace, usedSecrets); err != nil {
+			return nil, err
 		}
 	}
 
-	return usedSecrets
+	return usedSecrets, nil
 }
 
-func (d *determiner) detectUsedPersistentVolumeClaims() map[string]struct{} {
-	usedPersistentVolumeClaims := make(map[string]struct{})
+func (d *determiner) detectUsedPersistentVolumeClaims() references {
+	usedPersistentVolumeClaims := make(references)
 
-	for _, pod := range d.pods {
-		for _, volume := range pod.Spec.Volumes {
-			if volume.PersistentVolumeClaim == nil {
-				continue
-			}
-			usedPersistentVolumeClaims[volume.PersistentVolumeClaim.ClaimName] = struct{}{}
+	for _, owner := range d.podSpecOwners() {
+		for _, name := range extractPodSpecReferences(owner.spec).persistentVolumeClaims {
+			usedPersistentVolumeClaims.add(types.NamespacedName{Namespace: owner.ref.Namespace, Name: name}, owner.ref)
 		}
 	}
 
 	return usedPersistentVolumeClaims
 }
 
-func (d *determiner) determineUsedPodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget) (bool, error) {
-	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+// isStatefulSetClaim returns true if the claim is created from one of the StatefulSet's volumeClaimTemplates.
+// Such a claim is named "<template>-<statefulset>-<ordinal>" and survives scaling the StatefulSet down.
+func isStatefulSetClaim(sts *appsv1.StatefulSet, claimName string) bool {
+	for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+		prefix := fmt.Sprintf("%s-%s-", tmpl.Name, sts.Name)
+		if !strings.HasPrefix(claimName, prefix) {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(claimName, prefix)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// determineUsedPodDisruptionBudget returns the Pods selected by the PodDisruptionBudget.
+func (d *determiner) determineUsedPodDisruptionBudget(pdb *resource.PodDisruptionBudget) ([]Reference, error) {
+	selector, err := pdb.LabelSelector()
 	if err != nil {
-		return false, fmt.Errorf("invalid label selector (%s): %w", pdb.Name, err)
+		return nil, fmt.Errorf("invalid label selector (%s): %w", pdb.Name, err)
 	}
 
+	var pods []Reference
+
 	for _, pod := range d.pods {
+		if pod.Namespace != pdb.Namespace {
+			continue
+		}
 		if selector.Matches(labels.Set(pod.Labels)) {
-			return true, nil
+			pods = append(pods, referenceTo(resource.KindPod, pod))
 		}
 	}
 
-	return false, nil
+	return pods, nil
+}
+
+func referenceTo(kind string, obj metav1.Object) Reference {
+	return Reference{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
 }
@@ -9,10 +9,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	cliresource "k8s.io/cli-runtime/pkg/resource"
 
 	"github.com/micnncim/kubectl-reap/pkg/resource"
@@ -20,6 +23,8 @@ import (
 
 func Test_determiner_DetermineDeletion(t *testing.T) {
 	const (
+		fakeNamespace1            = "fake-ns1"
+		fakeNamespace2            = "fake-ns2"
 		fakePod                   = "fake-pod"
 		fakeConfigMap             = "fake-cm"
 		fakeSecret                = "fake-secret"
@@ -35,10 +40,11 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 	fakeTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	type fields struct {
-		usedConfigMaps        map[string]struct{}
-		usedSecrets           map[string]struct{}
-		usedPersistentVolumes map[string]struct{}
+		usedConfigMaps        references
+		usedSecrets           references
+		usedPersistentVolumes references
 		pods                  []*corev1.Pod
+		statefulSets          []*appsv1.StatefulSet
 	}
 	type args struct {
 		info *cliresource.Info
@@ -91,7 +97,8 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 			name: "ConfigMap should be deleted when it is not used",
 			args: args{
 				info: &cliresource.Info{
-					Name: fakeConfigMap,
+					Name:      fakeConfigMap,
+					Namespace: fakeNamespace1,
 					Object: &corev1.ConfigMap{
 						TypeMeta: metav1.TypeMeta{
 							Kind: resource.KindConfigMap,
@@ -105,13 +112,14 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 		{
 			name: "ConfigMap should not be deleted when it is used",
 			fields: fields{
-				usedConfigMaps: map[string]struct{}{
-					fakeConfigMap: {},
+				usedConfigMaps: references{
+					{Namespace: fakeNamespace1, Name: fakeConfigMap}: {},
 				},
 			},
 			args: args{
 				info: &cliresource.Info{
-					Name: fakeConfigMap,
+					Name:      fakeConfigMap,
+					Namespace: fakeNamespace1,
 					Object: &corev1.ConfigMap{
 						TypeMeta: metav1.TypeMeta{
 							Kind: resource.KindConfigMap,
@@ -122,11 +130,33 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "ConfigMap should be deleted when only the one with the same name in another namespace is used",
+			fields: fields{
+				usedConfigMaps: references{
+					{Namespace: fakeNamespace2, Name: fakeConfigMap}: {},
+				},
+			},
+			args: args{
+				info: &cliresource.Info{
+					Name:      fakeConfigMap,
+					Namespace: fakeNamespace1,
+					Object: &corev1.ConfigMap{
+						TypeMeta: metav1.TypeMeta{
+							Kind: resource.KindConfigMap,
+						},
+					},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
 		{
 			name: "Secret should be deleted when it is not used",
 			args: args{
 				info: &cliresource.Info{
-					Name: fakeSecret,
+					Name:      fakeSecret,
+					Namespace: fakeNamespace1,
 					Object: &corev1.Secret{
 						TypeMeta: metav1.TypeMeta{
 							Kind: resource.KindSecret,
@@ -140,13 +170,14 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 		{
 			name: "Secret should not be deleted when it is used",
 			fields: fields{
-				usedSecrets: map[string]struct{}{
-					fakeSecret: {},
+				usedSecrets: references{
+					{Namespace: fakeNamespace1, Name: fakeSecret}: {},
 				},
 			},
 			args: args{
 				info: &cliresource.Info{
-					Name: fakeSecret,
+					Name:      fakeSecret,
+					Namespace: fakeNamespace1,
 					Object: &corev1.Secret{
 						TypeMeta: metav1.TypeMeta{
 							Kind: resource.KindSecret,
@@ -157,11 +188,33 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "Secret should be deleted when only the one with the same name in another namespace is used",
+			fields: fields{
+				usedSecrets: references{
+					{Namespace: fakeNamespace2, Name: fakeSecret}: {},
+				},
+			},
+			args: args{
+				info: &cliresource.Info{
+					Name:      fakeSecret,
+					Namespace: fakeNamespace1,
+					Object: &corev1.Secret{
+						TypeMeta: metav1.TypeMeta{
+							Kind: resource.KindSecret,
+						},
+					},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
 		{
 			name: "PersistentVolumeClaim should be deleted when it is not used",
 			args: args{
 				info: &cliresource.Info{
-					Name: fakePersistentVolumeClaim,
+					Name:      fakePersistentVolumeClaim,
+					Namespace: fakeNamespace1,
 					Object: &corev1.PersistentVolumeClaim{
 						TypeMeta: metav1.TypeMeta{
 							Kind: resource.KindPersistentVolumeClaim,
@@ -175,13 +228,70 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 		{
 			name: "PersistentVolumeClaim should not be deleted when it is used",
 			fields: fields{
-				usedPersistentVolumes: map[string]struct{}{
-					fakePersistentVolumeClaim: {},
+				usedPersistentVolumes: references{
+					{Namespace: fakeNamespace1, Name: fakePersistentVolumeClaim}: {},
+				},
+			},
+			args: args{
+				info: &cliresource.Info{
+					Name:      fakePersistentVolumeClaim,
+					Namespace: fakeNamespace1,
+					Object: &corev1.PersistentVolumeClaim{
+						TypeMeta: metav1.TypeMeta{
+							Kind: resource.KindPersistentVolumeClaim,
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "PersistentVolumeClaim should be deleted when only the one with the same name in another namespace is used",
+			fields: fields{
+				usedPersistentVolumes: references{
+					{Namespace: fakeNamespace2, Name: fakePersistentVolumeClaim}: {},
+				},
+			},
+			args: args{
+				info: &cliresource.Info{
+					Name:      fakePersistentVolumeClaim,
+					Namespace: fakeNamespace1,
+					Object: &corev1.PersistentVolumeClaim{
+						TypeMeta: metav1.TypeMeta{
+							Kind: resource.KindPersistentVolumeClaim,
+						},
+					},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "PersistentVolumeClaim should not be deleted when it is created from a StatefulSet's volumeClaimTemplates",
+			fields: fields{
+				statefulSets: []*appsv1.StatefulSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "web",
+							Namespace: fakeNamespace1,
+						},
+						Spec: appsv1.StatefulSetSpec{
+							VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "data",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 			args: args{
 				info: &cliresource.Info{
-					Name: fakePersistentVolumeClaim,
+					Name:      "data-web-3",
+					Namespace: fakeNamespace1,
 					Object: &corev1.PersistentVolumeClaim{
 						TypeMeta: metav1.TypeMeta{
 							Kind: resource.KindPersistentVolumeClaim,
@@ -283,6 +393,45 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "PodDisruptionBudget should be deleted when only Pods in another namespace match it",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: fakeNamespace2,
+							Labels: map[string]string{
+								fakeLabelKey1: fakeLabelValue1,
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				info: &cliresource.Info{
+					Name:      fakePodDisruptionBudget,
+					Namespace: fakeNamespace1,
+					Object: &policyv1beta1.PodDisruptionBudget{
+						TypeMeta: metav1.TypeMeta{
+							Kind: resource.KindPodDisruptionBudget,
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fakePodDisruptionBudget,
+							Namespace: fakeNamespace1,
+						},
+						Spec: policyv1beta1.PodDisruptionBudgetSpec{
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									fakeLabelKey1: fakeLabelValue1,
+								},
+							},
+						},
+					},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -296,6 +445,7 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 				usedSecrets:                tt.fields.usedSecrets,
 				usedPersistentVolumeClaims: tt.fields.usedPersistentVolumes,
 				pods:                       tt.fields.pods,
+				statefulSets:               tt.fields.statefulSets,
 			}
 
 			got, err := d.DetermineDeletion(context.Background(), tt.args.info)
@@ -303,7 +453,7 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
+			if got.Delete != tt.want {
 				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
 			}
 		})
@@ -312,6 +462,9 @@ func Test_determiner_DetermineDeletion(t *testing.T) {
 
 func Test_determiner_DetermineDeletion_PersistentVolume(t *testing.T) {
 	const (
+		fakeNamespace              = "fake-ns"
+		fakeUID1                   = types.UID("fake-uid1")
+		fakeUID2                   = types.UID("fake-uid2")
 		fakePersistentVolume       = "fake-pv"
 		fakePersistentVolumeClaim1 = "fake-pvc1"
 		fakePersistentVolumeClaim2 = "fake-pvc2"
@@ -330,7 +483,8 @@ func Test_determiner_DetermineDeletion_PersistentVolume(t *testing.T) {
 	})
 
 	type fields struct {
-		persistentVolumeClaims []*corev1.PersistentVolumeClaim
+		persistentVolumeClaims            []*corev1.PersistentVolumeClaim
+		persistentVolumeCapacityHeuristic bool
 	}
 	type args struct {
 		info *cliresource.Info
@@ -344,8 +498,10 @@ func Test_determiner_DetermineDeletion_PersistentVolume(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:   "PersistentVolume should be deleted when it is not used",
-			fields: fields{},
+			name: "Available PersistentVolume should be deleted when it doesn't satisfy any claims with the heuristic",
+			fields: fields{
+				persistentVolumeCapacityHeuristic: true,
+			},
 			args: args{
 				info: &cliresource.Info{
 					Name: fakePersistentVolume,
@@ -358,6 +514,9 @@ func Test_determiner_DetermineDeletion_PersistentVolume(t *testing.T) {
 								fakeLabelKey: fakeLabelValue,
 							},
 						},
+						Status: corev1.PersistentVolumeStatus{
+							Phase: corev1.VolumeAvailable,
+						},
 					},
 				},
 			},
@@ -365,8 +524,9 @@ func Test_determiner_DetermineDeletion_PersistentVolume(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "PersistentVolume should not be deleted when it is used",
+			name: "Available PersistentVolume should not be deleted when it satisfies a claim with the heuristic",
 			fields: fields{
+				persistentVolumeCapacityHeuristic: true,
 				persistentVolumeClaims: []*corev1.PersistentVolumeClaim{
 					{
 						ObjectMeta: metav1.ObjectMeta{
@@ -395,12 +555,129 @@ func Test_determiner_DetermineDeletion_PersistentVolume(t *testing.T) {
 								fakeLabelKey: fakeLabelValue,
 							},
 						},
+						Status: corev1.PersistentVolumeStatus{
+							Phase: corev1.VolumeAvailable,
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Available PersistentVolume should not be deleted without the heuristic",
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeAvailable, corev1.PersistentVolumeReclaimRetain, nil),
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Available PersistentVolume pre-bound to a claim yet to be created should not be deleted without the heuristic",
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeAvailable, corev1.PersistentVolumeReclaimRetain, &corev1.ObjectReference{
+					Name:      fakePersistentVolumeClaim1,
+					Namespace: fakeNamespace,
+				}),
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Available PersistentVolume pre-bound to a missing claim should be deleted with the heuristic",
+			fields: fields{
+				persistentVolumeCapacityHeuristic: true,
+			},
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeAvailable, corev1.PersistentVolumeReclaimRetain, &corev1.ObjectReference{
+					Name:      fakePersistentVolumeClaim1,
+					Namespace: fakeNamespace,
+				}),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Released PersistentVolume should be deleted when its reclaim policy is Retain",
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeReleased, corev1.PersistentVolumeReclaimRetain, nil),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Released PersistentVolume should not be deleted when its reclaim policy is Delete",
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeReleased, corev1.PersistentVolumeReclaimDelete, nil),
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Failed PersistentVolume should be deleted",
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeFailed, corev1.PersistentVolumeReclaimDelete, nil),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Bound PersistentVolume should not be deleted when its claim exists",
+			fields: fields{
+				persistentVolumeClaims: []*corev1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fakePersistentVolumeClaim1,
+							Namespace: fakeNamespace,
+							UID:       fakeUID1,
+						},
 					},
 				},
 			},
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeBound, corev1.PersistentVolumeReclaimRetain, &corev1.ObjectReference{
+					Name:      fakePersistentVolumeClaim1,
+					Namespace: fakeNamespace,
+					UID:       fakeUID1,
+				}),
+			},
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "Bound PersistentVolume should be deleted when its claim has been re-created",
+			fields: fields{
+				persistentVolumeClaims: []*corev1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fakePersistentVolumeClaim1,
+							Namespace: fakeNamespace,
+							UID:       fakeUID2,
+						},
+					},
+				},
+			},
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeBound, corev1.PersistentVolumeReclaimRetain, &corev1.ObjectReference{
+					Name:      fakePersistentVolumeClaim1,
+					Namespace: fakeNamespace,
+					UID:       fakeUID1,
+				}),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Bound PersistentVolume should be deleted when its claim doesn't exist",
+			args: args{
+				info: newPersistentVolumeInfo(corev1.VolumeBound, corev1.PersistentVolumeReclaimRetain, &corev1.ObjectReference{
+					Name:      fakePersistentVolumeClaim1,
+					Namespace: fakeNamespace,
+				}),
+			},
+			want:    true,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -410,7 +687,8 @@ func Test_determiner_DetermineDeletion_PersistentVolume(t *testing.T) {
 			t.Parallel()
 
 			d := &determiner{
-				persistentVolumeClaims: tt.fields.persistentVolumeClaims,
+				persistentVolumeClaims:            tt.fields.persistentVolumeClaims,
+				persistentVolumeCapacityHeuristic: tt.fields.persistentVolumeCapacityHeuristic,
 			}
 
 			got, err := d.DetermineDeletion(context.Background(), tt.args.info)
@@ -418,13 +696,36 @@ func Test_determiner_DetermineDeletion_PersistentVolume(t *testing.T) {
 				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
+			if got.Delete != tt.want {
 				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func newPersistentVolumeInfo(phase corev1.PersistentVolumePhase, policy corev1.PersistentVolumeReclaimPolicy, claimRef *corev1.ObjectReference) *cliresource.Info {
+	const fakePersistentVolume = "fake-pv"
+
+	return &cliresource.Info{
+		Name: fakePersistentVolume,
+		Object: &corev1.PersistentVolume{
+			TypeMeta: metav1.TypeMeta{
+				Kind: resource.KindPersistentVolume,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fakePersistentVolume,
+			},
+			Spec: corev1.PersistentVolumeSpec{
+				ClaimRef:                      claimRef,
+				PersistentVolumeReclaimPolicy: policy,
+			},
+			Status: corev1.PersistentVolumeStatus{
+				Phase: phase,
+			},
+		},
+	}
+}
+
 func Test_determiner_DetermineDeletion_HorizontalPodAutoscaler(t *testing.T) {
 	const (
 		fakeNamespace                = "fake-ns"
@@ -507,6 +808,59 @@ func Test_determiner_DetermineDeletion_HorizontalPodAutoscaler(t *testing.T) {
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "autoscaling/v2 HorizontalPodAutoscaler should not be deleted when it is used",
+			args: args{
+				info: &cliresource.Info{
+					Name:      fakeHorizontalPodAutoscaler,
+					Namespace: fakeNamespace,
+					Object: &unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": "autoscaling/v2",
+							"kind":       resource.KindHorizontalPodAutoscaler,
+							"metadata": map[string]interface{}{
+								"name":      fakeHorizontalPodAutoscaler,
+								"namespace": fakeNamespace,
+							},
+							"spec": map[string]interface{}{
+								"scaleTargetRef": map[string]interface{}{
+									"apiVersion": fakeScaleTargetRefAPIVersion,
+									"kind":       fakeScaleTargetRefKind,
+									"name":       fakeScaleTargetRefName,
+								},
+								"maxReplicas": int64(3),
+								"metrics": []interface{}{
+									map[string]interface{}{
+										"type": "Resource",
+										"resource": map[string]interface{}{
+											"name": "memory",
+											"target": map[string]interface{}{
+												"type":               "Utilization",
+												"averageUtilization": int64(80),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			fakeObjects: []runtime.Object{
+				&appsv1.Deployment{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: fakeScaleTargetRefAPIVersion,
+						Kind:       fakeScaleTargetRefKind,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeScaleTargetRefName,
+						Namespace: fakeNamespace,
+					},
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -530,7 +884,7 @@ func Test_determiner_DetermineDeletion_HorizontalPodAutoscaler(t *testing.T) {
 				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
+			if got.Delete != tt.want {
 				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
 			}
 		})
@@ -552,7 +906,7 @@ func Test_determiner_determineUsedPodDisruptionBudget(t *testing.T) {
 		pods []*corev1.Pod
 	}
 	type args struct {
-		pdb *policyv1beta1.PodDisruptionBudget
+		pdb *resource.PodDisruptionBudget
 	}
 
 	tests := []struct {
@@ -584,11 +938,11 @@ func Test_determiner_determineUsedPodDisruptionBudget(t *testing.T) {
 				},
 			},
 			args: args{
-				pdb: &policyv1beta1.PodDisruptionBudget{
+				pdb: &resource.PodDisruptionBudget{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: fakePodDisruptionBudget,
 					},
-					Spec: policyv1beta1.PodDisruptionBudgetSpec{
+					Spec: resource.PodDisruptionBudgetSpec{
 						Selector: &metav1.LabelSelector{
 							MatchLabels: map[string]string{
 								fakeLabelKey1: fakeLabelValue1,
@@ -614,11 +968,11 @@ func Test_determiner_determineUsedPodDisruptionBudget(t *testing.T) {
 				},
 			},
 			args: args{
-				pdb: &policyv1beta1.PodDisruptionBudget{
+				pdb: &resource.PodDisruptionBudget{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: fakePodDisruptionBudget,
 					},
-					Spec: policyv1beta1.PodDisruptionBudgetSpec{
+					Spec: resource.PodDisruptionBudgetSpec{
 						Selector: &metav1.LabelSelector{
 							MatchExpressions: []metav1.LabelSelectorRequirement{
 								{
@@ -648,11 +1002,11 @@ func Test_determiner_determineUsedPodDisruptionBudget(t *testing.T) {
 				},
 			},
 			args: args{
-				pdb: &policyv1beta1.PodDisruptionBudget{
+				pdb: &resource.PodDisruptionBudget{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: fakePodDisruptionBudget,
 					},
-					Spec: policyv1beta1.PodDisruptionBudgetSpec{
+					Spec: resource.PodDisruptionBudgetSpec{
 						Selector: &metav1.LabelSelector{
 							MatchLabels: map[string]string{
 								fakeLabelKey1: fakeLabelValue1,
@@ -664,6 +1018,90 @@ func Test_determiner_determineUsedPodDisruptionBudget(t *testing.T) {
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "used PodDisruptionBudget should be determined with empty selector in policy/v1",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								fakeLabelKey2: fakeLabelValue2,
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				pdb: &resource.PodDisruptionBudget{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: resource.APIVersionPolicyV1,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: fakePodDisruptionBudget,
+					},
+					Spec: resource.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{},
+					},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "used PodDisruptionBudget should not be determined with empty selector in policy/v1beta1",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								fakeLabelKey2: fakeLabelValue2,
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				pdb: &resource.PodDisruptionBudget{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: resource.APIVersionPolicyV1beta1,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: fakePodDisruptionBudget,
+					},
+					Spec: resource.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{},
+					},
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "used PodDisruptionBudget should not be determined with nil selector",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								fakeLabelKey2: fakeLabelValue2,
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				pdb: &resource.PodDisruptionBudget{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: resource.APIVersionPolicyV1,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: fakePodDisruptionBudget,
+					},
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -682,7 +1120,7 @@ func Test_determiner_determineUsedPodDisruptionBudget(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tt.want, got); diff != "" {
+			if diff := cmp.Diff(tt.want, len(got) > 0); diff != "" {
 				t.Errorf("(-want +got):\n%s", diff)
 			}
 		})
@@ -702,7 +1140,7 @@ func Test_determiner_determineUsedSecret(t *testing.T) {
 		name   string
 		fields fields
 		args   args
-		want   map[string]struct{}
+		want   references
 	}{
 		{
 			name: "secrets used in ImagePullSecret should be determined as used",
@@ -717,7 +1155,7 @@ func Test_determiner_determineUsedSecret(t *testing.T) {
 			args: args{
 				secret: fakeSecret,
 			},
-			want: map[string]struct{}{fakeSecret: {}},
+			want: references{{Name: fakeSecret}: {{Kind: resource.KindPod}}},
 		},
 		{
 			name: "secrets used in EnvFrom should be determined as used",
@@ -735,7 +1173,25 @@ func Test_determiner_determineUsedSecret(t *testing.T) {
 			args: args{
 				secret: fakeSecret,
 			},
-			want: map[string]struct{}{fakeSecret: {}},
+			want: references{{Name: fakeSecret}: {{Kind: resource.KindPod}}},
+		},
+		{
+			name: "secrets used in EnvFrom of init containers should be determined as used",
+			fields: fields{
+				pods: []*corev1.Pod{{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{{
+							EnvFrom: []corev1.EnvFromSource{
+								{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: fakeSecret}}},
+							},
+						}},
+					},
+				}},
+			},
+			args: args{
+				secret: fakeSecret,
+			},
+			want: references{{Name: fakeSecret}: {{Kind: resource.KindPod}}},
 		},
 	}
 	for _, tt := range tests {
@@ -744,10 +1200,157 @@ func Test_determiner_determineUsedSecret(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
+			c, err := resource.NewFakeClient()
+			if err != nil {
+				t.Errorf("failed to construct fake resource client")
+				return
+			}
+
 			d := &determiner{
-				pods: tt.fields.pods,
+				resourceClient: c,
+				pods:           tt.fields.pods,
+			}
+			got, err := d.detectUsedSecrets(context.Background(), metav1.NamespaceAll)
+			if err != nil {
+				t.Errorf("determiner.detectUsedSecrets() error = %v", err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_determiner_detectUsedConfigMaps(t *testing.T) {
+	const (
+		fakeNamespace1 = "fake-ns1"
+		fakeNamespace2 = "fake-ns2"
+		fakeConfigMap  = "fake-cm"
+	)
+
+	type fields struct {
+		pods        []*corev1.Pod
+		replicaSets []*appsv1.ReplicaSet
+		cronJobs    []*batchv1beta1.CronJob
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   references
+	}{
+		{
+			name: "ConfigMaps should be determined as used only in the namespace of the referencing Pod",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: fakeNamespace2,
+						},
+						Spec: corev1.PodSpec{
+							Volumes: []corev1.Volume{
+								{
+									VolumeSource: corev1.VolumeSource{
+										ConfigMap: &corev1.ConfigMapVolumeSource{
+											LocalObjectReference: corev1.LocalObjectReference{Name: fakeConfigMap},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: references{
+				{Namespace: fakeNamespace2, Name: fakeConfigMap}: {{Kind: resource.KindPod, Namespace: fakeNamespace2}},
+			},
+		},
+		{
+			name: "ConfigMaps with the same name should be determined as used in each namespace",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: fakeNamespace1,
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								EnvFrom: []corev1.EnvFromSource{
+									{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: fakeConfigMap}}},
+								},
+							}},
+						},
+					},
+				},
+				replicaSets: []*appsv1.ReplicaSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: fakeNamespace2,
+						},
+						Spec: appsv1.ReplicaSetSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{{
+										EnvFrom: []corev1.EnvFromSource{
+											{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: fakeConfigMap}}},
+										},
+									}},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: references{
+				{Namespace: fakeNamespace1, Name: fakeConfigMap}: {{Kind: resource.KindPod, Namespace: fakeNamespace1}},
+				{Namespace: fakeNamespace2, Name: fakeConfigMap}: {{Kind: resource.KindReplicaSet, Namespace: fakeNamespace2}},
+			},
+		},
+		{
+			name: "ConfigMaps referenced by CronJobs should be determined as used",
+			fields: fields{
+				cronJobs: []*batchv1beta1.CronJob{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: fakeNamespace1,
+						},
+						Spec: batchv1beta1.CronJobSpec{
+							JobTemplate: batchv1beta1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: corev1.PodTemplateSpec{
+										Spec: corev1.PodSpec{
+											Containers: []corev1.Container{{
+												EnvFrom: []corev1.EnvFromSource{
+													{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: fakeConfigMap}}},
+												},
+											}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: references{
+				{Namespace: fakeNamespace1, Name: fakeConfigMap}: {{Kind: resource.KindCronJob, Namespace: fakeNamespace1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &determiner{
+				pods:        tt.fields.pods,
+				replicaSets: tt.fields.replicaSets,
+				cronJobs:    tt.fields.cronJobs,
 			}
-			got := d.detectUsedSecrets(nil)
+			got := d.detectUsedConfigMaps()
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Errorf("(-want +got):\n%s", diff)
 			}
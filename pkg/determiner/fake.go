@@ -57,7 +57,7 @@ func NewFakeDeterminer(objects ...runtime.Object) (*FakeDeterminer, error) {
 	}, nil
 }
 
-func (d *FakeDeterminer) DetermineDeletion(_ context.Context, info *cliresource.Info) (bool, error) {
+func (d *FakeDeterminer) DetermineDeletion(_ context.Context, info *cliresource.Info) (*Decision, error) {
 	key := fakeObjectKey{
 		kind:      info.Object.GetObjectKind().GroupVersionKind().Kind,
 		name:      info.Name,
@@ -68,5 +68,8 @@ func (d *FakeDeterminer) DetermineDeletion(_ context.Context, info *cliresource.
 	_, ok := d.fakeObjectsToBeDeleted[key]
 	d.mu.RUnlock()
 
-	return ok, nil
+	if !ok {
+		return keepDecision(nil, "not to be deleted"), nil
+	}
+	return deleteDecision("to be deleted"), nil
 }
@@ -0,0 +1,118 @@
+package determiner
+
+import (
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// Default values of CronJob's successfulJobsHistoryLimit and failedJobsHistoryLimit.
+	defaultSuccessfulJobsHistoryLimit = 3
+	defaultFailedJobsHistoryLimit     = 1
+)
+
+// jobFinishedCondition returns the Complete or Failed condition of the Job.
+// It returns nil if the Job has not finished yet.
+func jobFinishedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		c := &job.Status.Conditions[i]
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return c
+		}
+	}
+
+	// Fall back to the completion time for Jobs whose conditions are not populated.
+	if job.Status.CompletionTime != nil {
+		return &batchv1.JobCondition{
+			Type:               batchv1.JobComplete,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: *job.Status.CompletionTime,
+		}
+	}
+
+	return nil
+}
+
+// jobTTLExpired returns true if the Job's ttlSecondsAfterFinished has passed, or if it's not set.
+func jobTTLExpired(job *batchv1.Job, finished *batchv1.JobCondition, now time.Time) bool {
+	if job.Spec.TTLSecondsAfterFinished == nil {
+		return true
+	}
+
+	ttl := time.Duration(*job.Spec.TTLSecondsAfterFinished) * time.Second
+	return !now.Before(finished.LastTransitionTime.Add(ttl))
+}
+
+// detectPreservedJobs returns the newest finished Jobs of each CronJob which are kept by its history limits.
+func (d *determiner) detectPreservedJobs() map[types.NamespacedName]struct{} {
+	preservedJobs := make(map[types.NamespacedName]struct{})
+
+	for _, cj := range d.cronJobs {
+		var succeeded, failed []*batchv1.Job
+
+		for _, job := range d.jobs {
+			if job.Namespace != cj.Namespace || !isControlledBy(job.OwnerReferences, cj.UID) {
+				continue
+			}
+
+			c := jobFinishedCondition(job)
+			switch {
+			case c == nil:
+				continue
+			case c.Type == batchv1.JobComplete:
+				succeeded = append(succeeded, job)
+			default:
+				failed = append(failed, job)
+			}
+		}
+
+		for _, job := range newestJobs(succeeded, historyLimit(cj.Spec.SuccessfulJobsHistoryLimit, defaultSuccessfulJobsHistoryLimit)) {
+			preservedJobs[types.NamespacedName{Namespace: job.Namespace, Name: job.Name}] = struct{}{}
+		}
+		for _, job := range newestJobs(failed, historyLimit(cj.Spec.FailedJobsHistoryLimit, defaultFailedJobsHistoryLimit)) {
+			preservedJobs[types.NamespacedName{Namespace: job.Namespace, Name: job.Name}] = struct{}{}
+		}
+	}
+
+	return preservedJobs
+}
+
+// newestJobs returns at most n Jobs started most recently.
+func newestJobs(jobs []*batchv1.Job, n int) []*batchv1.Job {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobStartTime(jobs[j]).Before(jobStartTime(jobs[i]))
+	})
+
+	if len(jobs) > n {
+		return jobs[:n]
+	}
+	return jobs
+}
+
+func jobStartTime(job *batchv1.Job) time.Time {
+	if job.Status.StartTime != nil {
+		return job.Status.StartTime.Time
+	}
+	return job.CreationTimestamp.Time
+}
+
+func historyLimit(limit *int32, defaultLimit int) int {
+	if limit == nil {
+		return defaultLimit
+	}
+	return int(*limit)
+}
+
+func isControlledBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.Controller != nil && *ref.Controller && ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package determiner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_determiner_DetermineDeletion_Job(t *testing.T) {
+	const (
+		fakeNamespace = "fake-ns"
+		fakeJob       = "fake-job"
+	)
+
+	fakeNow := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeTTLSeconds := int32(3600)
+
+	type fields struct {
+		preservedJobs map[types.NamespacedName]struct{}
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		job    *batchv1.Job
+		want   bool
+	}{
+		{
+			name: "Job should be deleted when it is failed",
+			job: &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(fakeNow.Add(-time.Minute))},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Job should be deleted when it is completed",
+			job: &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(fakeNow.Add(-time.Minute))},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Job should not be deleted when it is running",
+			job: &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Active: 1,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Job should not be deleted before its ttlSecondsAfterFinished expires",
+			job: &batchv1.Job{
+				Spec: batchv1.JobSpec{
+					TTLSecondsAfterFinished: &fakeTTLSeconds,
+				},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(fakeNow.Add(-time.Minute))},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Job should be deleted after its ttlSecondsAfterFinished expires",
+			job: &batchv1.Job{
+				Spec: batchv1.JobSpec{
+					TTLSecondsAfterFinished: &fakeTTLSeconds,
+				},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(fakeNow.Add(-2 * time.Hour))},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Job should not be deleted when it is kept by CronJob's history limit",
+			fields: fields{
+				preservedJobs: map[types.NamespacedName]struct{}{
+					{Namespace: fakeNamespace, Name: fakeJob}: {},
+				},
+			},
+			job: &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(fakeNow.Add(-time.Minute))},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &determiner{
+				preservedJobs: tt.fields.preservedJobs,
+				clock:         func() time.Time { return fakeNow },
+			}
+
+			tt.job.TypeMeta = metav1.TypeMeta{Kind: resource.KindJob}
+			info := &cliresource.Info{
+				Name:      fakeJob,
+				Namespace: fakeNamespace,
+				Object:    tt.job,
+			}
+
+			got, err := d.DetermineDeletion(context.Background(), info)
+			if err != nil {
+				t.Errorf("determiner.DetermineDeletion() error = %v", err)
+				return
+			}
+			if got.Delete != tt.want {
+				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_determiner_detectPreservedJobs(t *testing.T) {
+	const (
+		fakeNamespace = "fake-ns"
+		fakeCronJob   = "fake-cj"
+		fakeUID       = types.UID("fake-uid")
+	)
+
+	fakeTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	isController := true
+
+	newJob := func(name string, conditionType batchv1.JobConditionType, startedBefore time.Duration) *batchv1.Job {
+		start := metav1.NewTime(fakeTime.Add(-startedBefore))
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: fakeNamespace,
+				OwnerReferences: []metav1.OwnerReference{
+					{Name: fakeCronJob, UID: fakeUID, Controller: &isController},
+				},
+			},
+			Status: batchv1.JobStatus{
+				StartTime: &start,
+				Conditions: []batchv1.JobCondition{
+					{Type: conditionType, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	successfulJobsHistoryLimit := int32(2)
+
+	d := &determiner{
+		cronJobs: []*batchv1beta1.CronJob{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fakeCronJob,
+					Namespace: fakeNamespace,
+					UID:       fakeUID,
+				},
+				Spec: batchv1beta1.CronJobSpec{
+					SuccessfulJobsHistoryLimit: &successfulJobsHistoryLimit,
+				},
+			},
+		},
+		jobs: []*batchv1.Job{
+			newJob("succeeded-3", batchv1.JobComplete, 3*time.Hour),
+			newJob("succeeded-1", batchv1.JobComplete, 1*time.Hour),
+			newJob("succeeded-2", batchv1.JobComplete, 2*time.Hour),
+			newJob("failed-2", batchv1.JobFailed, 2*time.Hour),
+			newJob("failed-1", batchv1.JobFailed, 1*time.Hour),
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "not-owned",
+					Namespace: fakeNamespace,
+				},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+		},
+	}
+
+	want := map[types.NamespacedName]struct{}{
+		{Namespace: fakeNamespace, Name: "succeeded-1"}: {},
+		{Namespace: fakeNamespace, Name: "succeeded-2"}: {},
+		{Namespace: fakeNamespace, Name: "failed-1"}:    {},
+	}
+
+	got := d.detectPreservedJobs()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
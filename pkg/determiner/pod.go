@@ -0,0 +1,117 @@
+package determiner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PodReasonEvicted selects Failed Pods evicted by kubelet.
+	PodReasonEvicted = "Evicted"
+	// PodReasonOOMKilled selects Failed Pods having a container killed due to out of memory.
+	PodReasonOOMKilled = "OOMKilled"
+)
+
+// DefaultPendingOlderThan is the default minimum age of Pending Pods to be reaped,
+// which leaves Pods just waiting to be scheduled or pulling images alone.
+const DefaultPendingOlderThan = time.Hour
+
+// DefaultPodPhases is the list of Pod phases reaped by default.
+var DefaultPodPhases = []string{
+	string(corev1.PodSucceeded),
+	string(corev1.PodFailed),
+	string(corev1.PodUnknown),
+	string(corev1.PodPending),
+}
+
+// podPolicy determines whether a Pod should be reaped based on its phase.
+// The zero value reaps the Pods in DefaultPodPhases, and Pending ones older than DefaultPendingOlderThan.
+type podPolicy struct {
+	phases           map[string]struct{}
+	pendingOlderThan time.Duration
+}
+
+func newPodPolicy(phases []string, pendingOlderThan time.Duration) (*podPolicy, error) {
+	p := &podPolicy{
+		phases:           make(map[string]struct{}, len(phases)),
+		pendingOlderThan: pendingOlderThan,
+	}
+
+	for _, phase := range phases {
+		switch phase {
+		case string(corev1.PodSucceeded), string(corev1.PodFailed), string(corev1.PodUnknown), string(corev1.PodPending),
+			PodReasonEvicted, PodReasonOOMKilled:
+			p.phases[phase] = struct{}{}
+		default:
+			return nil, fmt.Errorf("unsupported Pod phase: %s", phase)
+		}
+	}
+
+	return p, nil
+}
+
+// shouldReap returns true if the Pod is in one of the selected phases.
+// Pending Pods are reaped only when they have been pending longer than pendingOlderThan.
+func (p *podPolicy) shouldReap(pod *corev1.Pod, now time.Time) bool {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded, corev1.PodUnknown:
+		return p.selects(string(pod.Status.Phase))
+
+	case corev1.PodFailed:
+		switch {
+		case p.selects(string(corev1.PodFailed)):
+			return true
+		case pod.Status.Reason == PodReasonEvicted:
+			return p.selects(PodReasonEvicted)
+		case isOOMKilled(pod):
+			return p.selects(PodReasonOOMKilled)
+		default:
+			return false
+		}
+
+	case corev1.PodPending:
+		if !p.selects(string(corev1.PodPending)) {
+			return false
+		}
+		return now.Sub(pod.CreationTimestamp.Time) >= p.minPendingAge()
+
+	default:
+		return false
+	}
+}
+
+func (p *podPolicy) minPendingAge() time.Duration {
+	if p.phases == nil { // zero value
+		return DefaultPendingOlderThan
+	}
+	return p.pendingOlderThan
+}
+
+func (p *podPolicy) selects(phase string) bool {
+	if p.phases == nil {
+		for _, defaultPhase := range DefaultPodPhases {
+			if phase == defaultPhase {
+				return true
+			}
+		}
+		return false
+	}
+
+	_, ok := p.phases[phase]
+	return ok
+}
+
+// isOOMKilled returns true if any container of the Pod was terminated due to out of memory.
+func isOOMKilled(pod *corev1.Pod) bool {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Terminated != nil && strings.EqualFold(status.State.Terminated.Reason, PodReasonOOMKilled) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package determiner
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_podPolicy_shouldReap(t *testing.T) {
+	fakeNow := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type fields struct {
+		phases           []string
+		pendingOlderThan time.Duration
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		pod    *corev1.Pod
+		want   bool
+	}{
+		{
+			name:   "running Pod should not be reaped",
+			fields: fields{phases: DefaultPodPhases},
+			pod:    &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+			want:   false,
+		},
+		{
+			name:   "succeeded Pod should be reaped",
+			fields: fields{phases: DefaultPodPhases},
+			pod:    &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+			want:   true,
+		},
+		{
+			name:   "succeeded Pod should not be reaped when the phase is not selected",
+			fields: fields{phases: []string{string(corev1.PodFailed)}},
+			pod:    &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+			want:   false,
+		},
+		{
+			name:   "evicted Pod should be reaped when Evicted is selected",
+			fields: fields{phases: []string{PodReasonEvicted}},
+			pod:    &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: PodReasonEvicted}},
+			want:   true,
+		},
+		{
+			name:   "failed Pod should not be reaped when only Evicted is selected",
+			fields: fields{phases: []string{PodReasonEvicted}},
+			pod:    &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed}},
+			want:   false,
+		},
+		{
+			name:   "OOMKilled Pod should be reaped when OOMKilled is selected",
+			fields: fields{phases: []string{PodReasonOOMKilled}},
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Reason: PodReasonOOMKilled},
+						},
+					}},
+				},
+			},
+			want: true,
+		},
+		{
+			name:   "recently created Pending Pod should not be reaped",
+			fields: fields{phases: DefaultPodPhases, pendingOlderThan: time.Hour},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(fakeNow.Add(-time.Minute))},
+				Status:     corev1.PodStatus{Phase: corev1.PodPending},
+			},
+			want: false,
+		},
+		{
+			name:   "long-stuck Pending Pod should be reaped",
+			fields: fields{phases: DefaultPodPhases, pendingOlderThan: time.Hour},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(fakeNow.Add(-2 * time.Hour))},
+				Status:     corev1.PodStatus{Phase: corev1.PodPending},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := newPodPolicy(tt.fields.phases, tt.fields.pendingOlderThan)
+			if err != nil {
+				t.Errorf("failed to construct Pod policy: %v", err)
+				return
+			}
+
+			if got := p.shouldReap(tt.pod, fakeNow); got != tt.want {
+				t.Errorf("podPolicy.shouldReap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_podPolicy_shouldReap_zeroValue(t *testing.T) {
+	fakeNow := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{
+			name: "recently created Pending Pod should not be reaped",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(fakeNow.Add(-time.Minute))},
+				Status:     corev1.PodStatus{Phase: corev1.PodPending},
+			},
+			want: false,
+		},
+		{
+			name: "Pending Pod older than DefaultPendingOlderThan should be reaped",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(fakeNow.Add(-DefaultPendingOlderThan))},
+				Status:     corev1.PodStatus{Phase: corev1.PodPending},
+			},
+			want: true,
+		},
+		{
+			name: "succeeded Pod should be reaped",
+			pod:  &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var p podPolicy
+			if got := p.shouldReap(tt.pod, fakeNow); got != tt.want {
+				t.Errorf("podPolicy.shouldReap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newPodPolicy(t *testing.T) {
+	if _, err := newPodPolicy([]string{"Running"}, 0); err == nil {
+		t.Errorf("newPodPolicy() should fail with unsupported phase")
+	}
+}
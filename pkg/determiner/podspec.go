@@ -0,0 +1,120 @@
+package determiner
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// podSpecReferences holds the names of objects referenced by a PodSpec.
+// The referenced objects always live in the same namespace as the PodSpec owner.
+type podSpecReferences struct {
+	configMaps             []string
+	secrets                []string
+	persistentVolumeClaims []string
+}
+
+// extractPodSpecReferences returns the ConfigMaps, Secrets and PersistentVolumeClaims referenced by spec
+// through its containers, init containers, ephemeral containers, volumes and image pull secrets.
+func extractPodSpecReferences(spec *corev1.PodSpec) *podSpecReferences {
+	refs := &podSpecReferences{}
+
+	for _, imagePullSecret := range spec.ImagePullSecrets {
+		refs.secrets = append(refs.secrets, imagePullSecret.Name)
+	}
+
+	for _, container := range spec.InitContainers {
+		refs.addEnv(container.EnvFrom, container.Env)
+	}
+	for _, container := range spec.Containers {
+		refs.addEnv(container.EnvFrom, container.Env)
+	}
+	for _, container := range spec.EphemeralContainers {
+		refs.addEnv(container.EnvFrom, container.Env)
+	}
+
+	for _, volume := range spec.Volumes {
+		refs.addVolume(volume)
+	}
+
+	return refs
+}
+
+func (r *podSpecReferences) addEnv(envFroms []corev1.EnvFromSource, envs []corev1.EnvVar) {
+	for _, envFrom := range envFroms {
+		if envFrom.ConfigMapRef != nil {
+			r.configMaps = append(r.configMaps, envFrom.ConfigMapRef.Name)
+		}
+		if envFrom.SecretRef != nil {
+			r.secrets = append(r.secrets, envFrom.SecretRef.Name)
+		}
+	}
+
+	for _, env := range envs {
+		if env.ValueFrom == nil {
+			continue
+		}
+		if env.ValueFrom.ConfigMapKeyRef != nil {
+			r.configMaps = append(r.configMaps, env.ValueFrom.ConfigMapKeyRef.Name)
+		}
+		if env.ValueFrom.SecretKeyRef != nil {
+			r.secrets = append(r.secrets, env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+}
+
+func (r *podSpecReferences) addVolume(volume corev1.Volume) {
+	if volume.ConfigMap != nil {
+		r.configMaps = append(r.configMaps, volume.ConfigMap.Name)
+	}
+
+	if volume.Secret != nil {
+		r.secrets = append(r.secrets, volume.Secret.SecretName)
+	}
+
+	r.addVolumeSecretRef(volume)
+
+	if volume.PersistentVolumeClaim != nil {
+		r.persistentVolumeClaims = append(r.persistentVolumeClaims, volume.PersistentVolumeClaim.ClaimName)
+	}
+
+	if volume.Projected != nil {
+		for _, source := range volume.Projected.Sources {
+			if source.ConfigMap != nil {
+				r.configMaps = append(r.configMaps, source.ConfigMap.Name)
+			}
+			if source.Secret != nil {
+				r.secrets = append(r.secrets, source.Secret.Name)
+			}
+		}
+	}
+}
+
+// addVolumeSecretRef adds the Secret which the volume plugin reads the credentials from.
+// All of them must live in the same namespace as the Pod.
+func (r *podSpecReferences) addVolumeSecretRef(volume corev1.Volume) {
+	var ref *corev1.LocalObjectReference
+
+	switch {
+	case volume.CSI != nil:
+		ref = volume.CSI.NodePublishSecretRef
+	case volume.CephFS != nil:
+		ref = volume.CephFS.SecretRef
+	case volume.RBD != nil:
+		ref = volume.RBD.SecretRef
+	case volume.Cinder != nil:
+		ref = volume.Cinder.SecretRef
+	case volume.FlexVolume != nil:
+		ref = volume.FlexVolume.SecretRef
+	case volume.ISCSI != nil:
+		ref = volume.ISCSI.SecretRef
+	case volume.ScaleIO != nil:
+		ref = volume.ScaleIO.SecretRef
+	case volume.StorageOS != nil:
+		ref = volume.StorageOS.SecretRef
+	case volume.AzureFile != nil && volume.AzureFile.SecretName != "":
+		ref = &corev1.LocalObjectReference{Name: volume.AzureFile.SecretName}
+	}
+
+	if ref != nil && ref.Name != "" {
+		r.secrets = append(r.secrets, ref.Name)
+	}
+}
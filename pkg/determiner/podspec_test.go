@@ -0,0 +1,121 @@
+package determiner
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_extractPodSpecReferences(t *testing.T) {
+	const (
+		fakeConfigMap1            = "fake-cm1"
+		fakeConfigMap2            = "fake-cm2"
+		fakeConfigMap3            = "fake-cm3"
+		fakeSecret1               = "fake-secret1"
+		fakeSecret2               = "fake-secret2"
+		fakeSecret3               = "fake-secret3"
+		fakePersistentVolumeClaim = "fake-pvc"
+	)
+
+	tests := []struct {
+		name string
+		spec *corev1.PodSpec
+		want *podSpecReferences
+	}{
+		{
+			name: "references in containers and volumes should be extracted",
+			spec: &corev1.PodSpec{
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: fakeSecret1}},
+				Containers: []corev1.Container{{
+					Env: []corev1.EnvVar{
+						{ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: fakeConfigMap1}}}},
+						{ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: fakeSecret2}}}},
+					},
+				}},
+				Volumes: []corev1.Volume{
+					{VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: fakePersistentVolumeClaim}}},
+					{VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{
+							{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: fakeConfigMap2}}},
+							{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: fakeSecret3}}},
+						},
+					}}},
+				},
+			},
+			want: &podSpecReferences{
+				configMaps:             []string{fakeConfigMap1, fakeConfigMap2},
+				secrets:                []string{fakeSecret1, fakeSecret2, fakeSecret3},
+				persistentVolumeClaims: []string{fakePersistentVolumeClaim},
+			},
+		},
+		{
+			name: "references in init containers and ephemeral containers should be extracted",
+			spec: &corev1.PodSpec{
+				InitContainers: []corev1.Container{{
+					EnvFrom: []corev1.EnvFromSource{
+						{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: fakeSecret1}}},
+						{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: fakeConfigMap1}}},
+					},
+				}},
+				EphemeralContainers: []corev1.EphemeralContainer{{
+					EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+						EnvFrom: []corev1.EnvFromSource{
+							{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: fakeConfigMap3}}},
+						},
+						Env: []corev1.EnvVar{
+							{ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: fakeSecret2}}}},
+						},
+					},
+				}},
+			},
+			want: &podSpecReferences{
+				configMaps: []string{fakeConfigMap1, fakeConfigMap3},
+				secrets:    []string{fakeSecret1, fakeSecret2},
+			},
+		},
+		{
+			name: "Secrets referenced by volume plugins should be extracted",
+			spec: &corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{NodePublishSecretRef: &corev1.LocalObjectReference{Name: "fake-csi"}}}},
+					{VolumeSource: corev1.VolumeSource{CephFS: &corev1.CephFSVolumeSource{SecretRef: &corev1.LocalObjectReference{Name: "fake-cephfs"}}}},
+					{VolumeSource: corev1.VolumeSource{RBD: &corev1.RBDVolumeSource{SecretRef: &corev1.LocalObjectReference{Name: "fake-rbd"}}}},
+					{VolumeSource: corev1.VolumeSource{Cinder: &corev1.CinderVolumeSource{SecretRef: &corev1.LocalObjectReference{Name: "fake-cinder"}}}},
+					{VolumeSource: corev1.VolumeSource{AzureFile: &corev1.AzureFileVolumeSource{SecretName: "fake-azurefile"}}},
+					{VolumeSource: corev1.VolumeSource{FlexVolume: &corev1.FlexVolumeSource{SecretRef: &corev1.LocalObjectReference{Name: "fake-flexvolume"}}}},
+					{VolumeSource: corev1.VolumeSource{ISCSI: &corev1.ISCSIVolumeSource{SecretRef: &corev1.LocalObjectReference{Name: "fake-iscsi"}}}},
+					{VolumeSource: corev1.VolumeSource{ScaleIO: &corev1.ScaleIOVolumeSource{SecretRef: &corev1.LocalObjectReference{Name: "fake-scaleio"}}}},
+					{VolumeSource: corev1.VolumeSource{StorageOS: &corev1.StorageOSVolumeSource{SecretRef: &corev1.LocalObjectReference{Name: "fake-storageos"}}}},
+					{VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{}}},
+				},
+			},
+			want: &podSpecReferences{
+				secrets: []string{
+					"fake-csi",
+					"fake-cephfs",
+					"fake-rbd",
+					"fake-cinder",
+					"fake-azurefile",
+					"fake-flexvolume",
+					"fake-iscsi",
+					"fake-scaleio",
+					"fake-storageos",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := extractPodSpecReferences(tt.spec)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(podSpecReferences{})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
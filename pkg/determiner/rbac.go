@@ -0,0 +1,203 @@
+package determiner
+
+import (
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+const (
+	// systemRolePrefix is the prefix of the names of ClusterRoles managed by Kubernetes components.
+	systemRolePrefix = "system:"
+	// aggregationLabelPrefix is the prefix of the labels conventionally used to aggregate ClusterRoles into others.
+	aggregationLabelPrefix = "rbac.authorization.k8s.io/aggregate-to-"
+)
+
+func (d *determiner) determineDeletionRole(info *cliresource.Info) (*Decision, error) {
+	if refs, ok := d.usedRoles[types.NamespacedName{Namespace: info.Namespace, Name: info.Name}]; ok {
+		return keepDecision(refs, "bound by RoleBindings"), nil
+	}
+	return deleteDecision("not bound by any RoleBindings"), nil
+}
+
+func (d *determiner) determineDeletionClusterRole(info *cliresource.Info) (*Decision, error) {
+	cr, err := resource.ObjectToClusterRole(info.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(cr.Name, systemRolePrefix) {
+		return keepDecision(nil, "system ClusterRole"), nil
+	}
+
+	if cr.AggregationRule != nil {
+		return keepDecision(nil, "aggregated ClusterRole"), nil
+	}
+
+	if refs, ok := d.usedClusterRoles[types.NamespacedName{Name: cr.Name}]; ok {
+		return keepDecision(refs, "bound by RoleBindings or ClusterRoleBindings"), nil
+	}
+
+	if refs := d.aggregatingClusterRoles(cr); len(refs) > 0 {
+		return keepDecision(refs, "aggregated into other ClusterRoles"), nil
+	}
+
+	for key := range cr.Labels {
+		if strings.HasPrefix(key, aggregationLabelPrefix) {
+			return keepDecision(nil, "labeled with %s to be aggregated into other ClusterRoles", key), nil
+		}
+	}
+
+	return deleteDecision("not bound by any RoleBindings or ClusterRoleBindings"), nil
+}
+
+// aggregatingClusterRoles returns the references to the ClusterRoles whose aggregationRule selects the ClusterRole.
+func (d *determiner) aggregatingClusterRoles(cr *rbacv1.ClusterRole) []Reference {
+	var refs []Reference
+
+	for _, aggregated := range d.clusterRoles {
+		if aggregated.AggregationRule == nil || aggregated.Name == cr.Name {
+			continue
+		}
+
+		for i := range aggregated.AggregationRule.ClusterRoleSelectors {
+			selector, err := metav1.LabelSelectorAsSelector(&aggregated.AggregationRule.ClusterRoleSelectors[i])
+			if err != nil || selector.Empty() || !selector.Matches(labels.Set(cr.Labels)) {
+				continue
+			}
+			refs = append(refs, referenceTo(resource.KindClusterRole, aggregated))
+			break
+		}
+	}
+
+	return refs
+}
+
+func (d *determiner) detectUsedRoles() references {
+	usedRoles := make(references)
+
+	for _, rb := range d.roleBindings {
+		if rb.RoleRef.Kind != resource.KindRole {
+			continue
+		}
+		// A RoleBinding can refer to a Role only in its namespace.
+		usedRoles.add(types.NamespacedName{Namespace: rb.Namespace, Name: rb.RoleRef.Name}, referenceTo(resource.KindRoleBinding, rb))
+	}
+
+	return usedRoles
+}
+
+func (d *determiner) detectUsedClusterRoles() references {
+	usedClusterRoles := make(references)
+
+	for _, rb := range d.roleBindings {
+		if rb.RoleRef.Kind != resource.KindClusterRole {
+			continue
+		}
+		usedClusterRoles.add(types.NamespacedName{Name: rb.RoleRef.Name}, referenceTo(resource.KindRoleBinding, rb))
+	}
+
+	for _, crb := range d.clusterRoleBindings {
+		if crb.RoleRef.Kind != resource.KindClusterRole {
+			continue
+		}
+		usedClusterRoles.add(types.NamespacedName{Name: crb.RoleRef.Name}, referenceTo(resource.KindClusterRoleBinding, crb))
+	}
+
+	return usedClusterRoles
+}
+
+func (d *determiner) determineDeletionRoleBinding(info *cliresource.Info) (*Decision, error) {
+	rb, err := resource.ObjectToRoleBinding(info.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.determineDeletionBinding(rb.RoleRef, rb.Subjects, rb.Namespace), nil
+}
+
+func (d *determiner) determineDeletionClusterRoleBinding(info *cliresource.Info) (*Decision, error) {
+	crb, err := resource.ObjectToClusterRoleBinding(info.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.determineDeletionBinding(crb.RoleRef, crb.Subjects, ""), nil
+}
+
+// determineDeletionBinding determines whether a RoleBinding or a ClusterRoleBinding in the namespace should be deleted.
+// Bindings with Users or Groups are kept even if their ServiceAccounts are missing,
+// since whether Users and Groups exist is unknown to the cluster.
+func (d *determiner) determineDeletionBinding(roleRef rbacv1.RoleRef, subjects []rbacv1.Subject, namespace string) *Decision {
+	if !d.roleRefExists(roleRef, namespace) {
+		return deleteDecision("bound to missing %s %s", roleRef.Kind, roleRef.Name)
+	}
+
+	role := Reference{Kind: roleRef.Kind, Name: roleRef.Name}
+	if roleRef.Kind == resource.KindRole {
+		role.Namespace = namespace
+	}
+
+	if len(subjects) == 0 {
+		return keepDecision([]Reference{role}, "bound to existing %s without subjects", roleRef.Kind)
+	}
+
+	sas := serviceAccountSubjects(subjects, namespace)
+
+	refs := []Reference{role}
+	for _, sa := range sas {
+		if d.serviceAccountExists(sa) {
+			refs = append(refs, Reference{Kind: resource.KindServiceAccount, Namespace: sa.Namespace, Name: sa.Name})
+		}
+	}
+
+	switch {
+	case len(refs) > 1:
+		return keepDecision(refs, "binding existing ServiceAccounts")
+	case len(sas) < len(subjects):
+		return keepDecision(refs, "binding Users or Groups")
+	default:
+		return deleteDecision("every ServiceAccount subject is missing")
+	}
+}
+
+// roleRefExists returns true if the Role or the ClusterRole the roleRef of a binding in the namespace points to exists.
+// roleRef of unknown kinds is regarded as existing.
+func (d *determiner) roleRefExists(ref rbacv1.RoleRef, namespace string) bool {
+	switch ref.Kind {
+	case resource.KindRole:
+		for _, role := range d.roles {
+			if role.Namespace == namespace && role.Name == ref.Name {
+				return true
+			}
+		}
+		return false
+
+	case resource.KindClusterRole:
+		for _, cr := range d.clusterRoles {
+			if cr.Name == ref.Name {
+				return true
+			}
+		}
+		return false
+
+	default:
+		return true
+	}
+}
+
+func (d *determiner) serviceAccountExists(key types.NamespacedName) bool {
+	for _, sa := range d.serviceAccounts {
+		if sa.Namespace == key.Namespace && sa.Name == key.Name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,433 @@
+package determiner
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_determiner_DetermineDeletion_Binding(t *testing.T) {
+	const (
+		fakeNamespace1     = "fake-ns1"
+		fakeNamespace2     = "fake-ns2"
+		fakeRole           = "fake-role"
+		fakeClusterRole    = "fake-cr"
+		fakeServiceAccount = "fake-sa"
+		fakeUser           = "fake-user"
+	)
+
+	newRoleBinding := func(roleRef rbacv1.RoleRef, subjects ...rbacv1.Subject) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				Kind: resource.KindRoleBinding,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fake-rb",
+				Namespace: fakeNamespace1,
+			},
+			RoleRef:  roleRef,
+			Subjects: subjects,
+		}
+	}
+
+	newClusterRoleBinding := func(roleRef rbacv1.RoleRef, subjects ...rbacv1.Subject) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				Kind: resource.KindClusterRoleBinding,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "fake-crb",
+			},
+			RoleRef:  roleRef,
+			Subjects: subjects,
+		}
+	}
+
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: resource.KindRole, Name: fakeRole}
+	clusterRoleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: resource.KindClusterRole, Name: fakeClusterRole}
+
+	serviceAccountSubject := func(namespace string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: namespace, Name: fakeServiceAccount}
+	}
+	userSubject := rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: fakeUser}
+
+	type fields struct {
+		roles           []*rbacv1.Role
+		clusterRoles    []*rbacv1.ClusterRole
+		serviceAccounts []*corev1.ServiceAccount
+	}
+
+	defaultFields := fields{
+		roles: []*rbacv1.Role{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace1, Name: fakeRole}},
+		},
+		clusterRoles: []*rbacv1.ClusterRole{
+			{ObjectMeta: metav1.ObjectMeta{Name: fakeClusterRole}},
+		},
+		serviceAccounts: []*corev1.ServiceAccount{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace1, Name: fakeServiceAccount}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		binding runtime.Object
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "RoleBinding should be deleted when its Role is missing",
+			fields:  fields{serviceAccounts: defaultFields.serviceAccounts},
+			binding: newRoleBinding(roleRef, serviceAccountSubject("")),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "RoleBinding should be deleted when only a Role with the same name in another namespace exists",
+			fields: fields{
+				roles: []*rbacv1.Role{
+					{ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace2, Name: fakeRole}},
+				},
+				serviceAccounts: defaultFields.serviceAccounts,
+			},
+			binding: newRoleBinding(roleRef, serviceAccountSubject("")),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "RoleBinding should be deleted when its ClusterRole is missing",
+			fields:  fields{roles: defaultFields.roles, serviceAccounts: defaultFields.serviceAccounts},
+			binding: newRoleBinding(clusterRoleRef, serviceAccountSubject("")),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "RoleBinding should be deleted when every ServiceAccount subject is missing",
+			fields:  defaultFields,
+			binding: newRoleBinding(roleRef, serviceAccountSubject(fakeNamespace2)),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "RoleBinding should not be deleted when it binds an existing ServiceAccount without namespace",
+			fields:  defaultFields,
+			binding: newRoleBinding(roleRef, serviceAccountSubject("")),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "RoleBinding should not be deleted when some ServiceAccount subjects exist",
+			fields:  defaultFields,
+			binding: newRoleBinding(clusterRoleRef, serviceAccountSubject(fakeNamespace2), serviceAccountSubject(fakeNamespace1)),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "RoleBinding should not be deleted when it binds Users besides missing ServiceAccounts",
+			fields:  defaultFields,
+			binding: newRoleBinding(roleRef, serviceAccountSubject(fakeNamespace2), userSubject),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "RoleBinding should not be deleted when it has no subjects",
+			fields:  defaultFields,
+			binding: newRoleBinding(roleRef),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "ClusterRoleBinding should be deleted when its ClusterRole is missing",
+			fields:  fields{serviceAccounts: defaultFields.serviceAccounts},
+			binding: newClusterRoleBinding(clusterRoleRef, serviceAccountSubject(fakeNamespace1)),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "ClusterRoleBinding should be deleted when every ServiceAccount subject is missing",
+			fields:  defaultFields,
+			binding: newClusterRoleBinding(clusterRoleRef, serviceAccountSubject(fakeNamespace2)),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "ClusterRoleBinding should not be deleted when it binds an existing ServiceAccount",
+			fields:  defaultFields,
+			binding: newClusterRoleBinding(clusterRoleRef, serviceAccountSubject(fakeNamespace1)),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "ClusterRoleBinding should not be deleted when it binds Users",
+			fields:  defaultFields,
+			binding: newClusterRoleBinding(clusterRoleRef, userSubject),
+			want:    false,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &determiner{
+				roles:           tt.fields.roles,
+				clusterRoles:    tt.fields.clusterRoles,
+				serviceAccounts: tt.fields.serviceAccounts,
+			}
+
+			obj := tt.binding.(metav1.Object)
+			info := &cliresource.Info{
+				Name:      obj.GetName(),
+				Namespace: obj.GetNamespace(),
+				Object:    tt.binding,
+			}
+
+			got, err := d.DetermineDeletion(context.Background(), info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Delete != tt.want {
+				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_determiner_DetermineDeletion_Role(t *testing.T) {
+	const (
+		fakeNamespace1 = "fake-ns1"
+		fakeNamespace2 = "fake-ns2"
+		fakeRole       = "fake-role"
+	)
+
+	newRoleBinding := func(namespace, kind string) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "fake-rb"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: kind, Name: fakeRole},
+		}
+	}
+
+	role := &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind: resource.KindRole,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fakeRole,
+			Namespace: fakeNamespace1,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		roleBindings []*rbacv1.RoleBinding
+		want         bool
+		wantErr      bool
+	}{
+		{
+			name:    "Role should be deleted when it is not bound",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:         "Role should be deleted when only a RoleBinding in another namespace refers to the name",
+			roleBindings: []*rbacv1.RoleBinding{newRoleBinding(fakeNamespace2, resource.KindRole)},
+			want:         true,
+			wantErr:      false,
+		},
+		{
+			name:         "Role should be deleted when only a RoleBinding to a ClusterRole with the same name exists",
+			roleBindings: []*rbacv1.RoleBinding{newRoleBinding(fakeNamespace1, resource.KindClusterRole)},
+			want:         true,
+			wantErr:      false,
+		},
+		{
+			name:         "Role should not be deleted when a RoleBinding in its namespace binds it",
+			roleBindings: []*rbacv1.RoleBinding{newRoleBinding(fakeNamespace1, resource.KindRole)},
+			want:         false,
+			wantErr:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &determiner{
+				roleBindings: tt.roleBindings,
+			}
+			d.usedRoles = d.detectUsedRoles()
+
+			info := &cliresource.Info{
+				Name:      role.Name,
+				Namespace: role.Namespace,
+				Object:    role,
+			}
+
+			got, err := d.DetermineDeletion(context.Background(), info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Delete != tt.want {
+				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_determiner_DetermineDeletion_ClusterRole(t *testing.T) {
+	const (
+		fakeNamespace   = "fake-ns"
+		fakeClusterRole = "fake-cr"
+		fakeLabelKey    = "fake-label-key"
+		fakeLabelValue  = "fake-label-value"
+	)
+
+	newClusterRole := func(name string, labels map[string]string, rule *rbacv1.AggregationRule) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{
+			TypeMeta: metav1.TypeMeta{
+				Kind: resource.KindClusterRole,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			AggregationRule: rule,
+		}
+	}
+
+	clusterRoleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: resource.KindClusterRole, Name: fakeClusterRole}
+	fakeAggregationRule := &rbacv1.AggregationRule{
+		ClusterRoleSelectors: []metav1.LabelSelector{
+			{MatchLabels: map[string]string{fakeLabelKey: fakeLabelValue}},
+		},
+	}
+
+	type fields struct {
+		clusterRoles        []*rbacv1.ClusterRole
+		roleBindings        []*rbacv1.RoleBinding
+		clusterRoleBindings []*rbacv1.ClusterRoleBinding
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		clusterRole *rbacv1.ClusterRole
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name:        "ClusterRole should be deleted when it is not bound",
+			clusterRole: newClusterRole(fakeClusterRole, nil, nil),
+			want:        true,
+			wantErr:     false,
+		},
+		{
+			name: "ClusterRole should be deleted when no aggregated ClusterRole selects it",
+			fields: fields{
+				clusterRoles: []*rbacv1.ClusterRole{newClusterRole("fake-aggregated", nil, fakeAggregationRule)},
+			},
+			clusterRole: newClusterRole(fakeClusterRole, map[string]string{fakeLabelKey: "other"}, nil),
+			want:        true,
+			wantErr:     false,
+		},
+		{
+			name: "ClusterRole should not be deleted when a RoleBinding binds it",
+			fields: fields{
+				roleBindings: []*rbacv1.RoleBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace, Name: "fake-rb"},
+						RoleRef:    clusterRoleRef,
+					},
+				},
+			},
+			clusterRole: newClusterRole(fakeClusterRole, nil, nil),
+			want:        false,
+			wantErr:     false,
+		},
+		{
+			name: "ClusterRole should not be deleted when a ClusterRoleBinding binds it",
+			fields: fields{
+				clusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-crb"},
+						RoleRef:    clusterRoleRef,
+					},
+				},
+			},
+			clusterRole: newClusterRole(fakeClusterRole, nil, nil),
+			want:        false,
+			wantErr:     false,
+		},
+		{
+			name: "ClusterRole should not be deleted when an aggregated ClusterRole selects it",
+			fields: fields{
+				clusterRoles: []*rbacv1.ClusterRole{newClusterRole("fake-aggregated", nil, fakeAggregationRule)},
+			},
+			clusterRole: newClusterRole(fakeClusterRole, map[string]string{fakeLabelKey: fakeLabelValue}, nil),
+			want:        false,
+			wantErr:     false,
+		},
+		{
+			name:        "ClusterRole should not be deleted when it is labeled to be aggregated",
+			clusterRole: newClusterRole(fakeClusterRole, map[string]string{"rbac.authorization.k8s.io/aggregate-to-view": "true"}, nil),
+			want:        false,
+			wantErr:     false,
+		},
+		{
+			name:        "aggregated ClusterRole should not be deleted",
+			clusterRole: newClusterRole(fakeClusterRole, nil, fakeAggregationRule),
+			want:        false,
+			wantErr:     false,
+		},
+		{
+			name:        "system ClusterRole should not be deleted",
+			clusterRole: newClusterRole("system:fake", nil, nil),
+			want:        false,
+			wantErr:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &determiner{
+				clusterRoles:        tt.fields.clusterRoles,
+				roleBindings:        tt.fields.roleBindings,
+				clusterRoleBindings: tt.fields.clusterRoleBindings,
+			}
+			d.usedClusterRoles = d.detectUsedClusterRoles()
+
+			info := &cliresource.Info{
+				Name:   tt.clusterRole.Name,
+				Object: tt.clusterRole,
+			}
+
+			got, err := d.DetermineDeletion(context.Background(), info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Delete != tt.want {
+				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
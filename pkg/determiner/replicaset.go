@@ -0,0 +1,159 @@
+package determiner
+
+import (
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+const (
+	// annotationRevision is the annotation the Deployment controller sets to the revision
+	// on both Deployments and their ReplicaSets.
+	annotationRevision = "deployment.kubernetes.io/revision"
+
+	// Default value of Deployment's revisionHistoryLimit.
+	defaultRevisionHistoryLimit = 10
+)
+
+func (d *determiner) determineDeletionReplicaSet(info *cliresource.Info) (*Decision, error) {
+	rs, err := resource.ObjectToReplicaSet(info.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := metav1.GetControllerOf(rs)
+	if owner != nil && owner.Kind != resource.KindDeployment {
+		return keepDecision([]Reference{{Kind: owner.Kind, Namespace: rs.Namespace, Name: owner.Name}}, "controlled by %s", owner.Kind), nil
+	}
+
+	deploy := d.controllingDeployment(rs, owner)
+	if deploy != nil && replicaSetRevision(rs) == deploymentRevision(deploy) {
+		return keepDecision([]Reference{referenceTo(resource.KindDeployment, deploy)}, "active revision of Deployment"), nil // should never touch the active revision
+	}
+
+	if replicas := replicaSetReplicas(rs); replicas > 0 {
+		return keepDecision(nil, "has %d replicas", replicas), nil // should not delete ReplicaSets running Pods
+	}
+
+	switch {
+	case deploy != nil && owner == nil:
+		return keepDecision([]Reference{referenceTo(resource.KindDeployment, deploy)}, "to be adopted by Deployment"), nil
+
+	case deploy != nil:
+		limit := historyLimit(deploy.Spec.RevisionHistoryLimit, defaultRevisionHistoryLimit)
+		if !d.beyondRevisionHistoryLimit(rs, deploy, limit) {
+			return keepDecision([]Reference{referenceTo(resource.KindDeployment, deploy)}, "within revisionHistoryLimit of Deployment"), nil
+		}
+		return deleteDecision("beyond revisionHistoryLimit %d of Deployment %s", limit, deploy.Name), nil
+
+	case owner != nil:
+		return deleteDecision("owner Deployment %s is missing", owner.Name), nil
+
+	case rs.Annotations[annotationRevision] != "":
+		return deleteDecision("orphaned from Deployment"), nil
+
+	default:
+		return keepDecision(nil, "not managed by Deployment"), nil
+	}
+}
+
+// controllingDeployment returns the Deployment which controls the ReplicaSet.
+// A ReplicaSet without controller is regarded as controlled by the Deployment which would adopt it.
+// It returns nil if there is no such Deployment.
+func (d *determiner) controllingDeployment(rs *appsv1.ReplicaSet, owner *metav1.OwnerReference) *appsv1.Deployment {
+	for _, deploy := range d.deployments {
+		if deploy.Namespace != rs.Namespace {
+			continue
+		}
+
+		if owner != nil {
+			if deploy.Name == owner.Name && (owner.UID == "" || deploy.UID == owner.UID) {
+				return deploy
+			}
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		if selector.Matches(labels.Set(rs.Labels)) {
+			return deploy
+		}
+	}
+
+	return nil
+}
+
+// beyondRevisionHistoryLimit returns true if the ReplicaSet is not one of the newest old revisions of the Deployment
+// kept by its revisionHistoryLimit. Like the Deployment controller, only old revisions without replicas are counted.
+func (d *determiner) beyondRevisionHistoryLimit(rs *appsv1.ReplicaSet, deploy *appsv1.Deployment, limit int) bool {
+	active := deploymentRevision(deploy)
+
+	var olds []*appsv1.ReplicaSet
+	for _, old := range d.replicaSets {
+		if old.Namespace != deploy.Namespace || !isControlledBy(old.OwnerReferences, deploy.UID) {
+			continue
+		}
+		if replicaSetRevision(old) == active || replicaSetReplicas(old) > 0 {
+			continue
+		}
+		olds = append(olds, old)
+	}
+
+	for _, kept := range newestReplicaSets(olds, limit) {
+		if kept.Namespace == rs.Namespace && kept.Name == rs.Name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newestReplicaSets returns at most n ReplicaSets of the highest revisions.
+func newestReplicaSets(replicaSets []*appsv1.ReplicaSet, n int) []*appsv1.ReplicaSet {
+	sort.SliceStable(replicaSets, func(i, j int) bool {
+		ri, rj := replicaSetRevision(replicaSets[i]), replicaSetRevision(replicaSets[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return replicaSets[j].CreationTimestamp.Before(&replicaSets[i].CreationTimestamp)
+	})
+
+	if len(replicaSets) > n {
+		return replicaSets[:n]
+	}
+	return replicaSets
+}
+
+// replicaSetReplicas returns the larger of the desired and the current number of replicas.
+func replicaSetReplicas(rs *appsv1.ReplicaSet) int32 {
+	replicas := rs.Status.Replicas
+	if rs.Spec.Replicas != nil && *rs.Spec.Replicas > replicas {
+		replicas = *rs.Spec.Replicas
+	}
+	return replicas
+}
+
+func replicaSetRevision(rs *appsv1.ReplicaSet) int64 {
+	return parseRevision(rs.Annotations[annotationRevision])
+}
+
+func deploymentRevision(deploy *appsv1.Deployment) int64 {
+	return parseRevision(deploy.Annotations[annotationRevision])
+}
+
+// parseRevision parses the revision annotation. Missing or invalid revisions are regarded as 0.
+func parseRevision(s string) int64 {
+	revision, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
@@ -0,0 +1,215 @@
+package determiner
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_determiner_DetermineDeletion_ReplicaSet(t *testing.T) {
+	const (
+		fakeNamespace  = "fake-ns"
+		fakeDeployment = "fake-deploy"
+		fakeUID        = types.UID("fake-uid")
+		fakeLabelKey   = "fake-label-key"
+		fakeLabelValue = "fake-label-value"
+	)
+
+	isController := true
+	limit0, limit2 := int32(0), int32(2)
+
+	newDeployment := func(revision int, limit *int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fakeDeployment,
+				Namespace:   fakeNamespace,
+				UID:         fakeUID,
+				Annotations: map[string]string{annotationRevision: strconv.Itoa(revision)},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector:             &metav1.LabelSelector{MatchLabels: map[string]string{fakeLabelKey: fakeLabelValue}},
+				RevisionHistoryLimit: limit,
+			},
+		}
+	}
+
+	newReplicaSet := func(revision int, replicas int32, owner *metav1.OwnerReference) *appsv1.ReplicaSet {
+		rs := &appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind: resource.KindReplicaSet,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fakeDeployment + "-" + strconv.Itoa(revision),
+				Namespace:   fakeNamespace,
+				Labels:      map[string]string{fakeLabelKey: fakeLabelValue},
+				Annotations: map[string]string{annotationRevision: strconv.Itoa(revision)},
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Replicas: &replicas,
+			},
+		}
+		if owner != nil {
+			rs.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return rs
+	}
+
+	deploymentOwner := &metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       resource.KindDeployment,
+		Name:       fakeDeployment,
+		UID:        fakeUID,
+		Controller: &isController,
+	}
+
+	// revisions 1 to 3 are old and revision 4 is active
+	ownedReplicaSets := []*appsv1.ReplicaSet{
+		newReplicaSet(1, 0, deploymentOwner),
+		newReplicaSet(2, 0, deploymentOwner),
+		newReplicaSet(3, 0, deploymentOwner),
+		newReplicaSet(4, 3, deploymentOwner),
+	}
+
+	type fields struct {
+		replicaSets []*appsv1.ReplicaSet
+		deployments []*appsv1.Deployment
+	}
+
+	tests := []struct {
+		name       string
+		fields     fields
+		replicaSet *appsv1.ReplicaSet
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name: "ReplicaSet should be deleted when it is beyond revisionHistoryLimit",
+			fields: fields{
+				replicaSets: ownedReplicaSets,
+				deployments: []*appsv1.Deployment{newDeployment(4, &limit2)},
+			},
+			replicaSet: newReplicaSet(1, 0, deploymentOwner),
+			want:       true,
+			wantErr:    false,
+		},
+		{
+			name: "ReplicaSet should be deleted when revisionHistoryLimit is zero",
+			fields: fields{
+				replicaSets: ownedReplicaSets,
+				deployments: []*appsv1.Deployment{newDeployment(4, &limit0)},
+			},
+			replicaSet: newReplicaSet(3, 0, deploymentOwner),
+			want:       true,
+			wantErr:    false,
+		},
+		{
+			name: "ReplicaSet should be deleted when its owner Deployment is missing",
+			fields: fields{
+				replicaSets: ownedReplicaSets,
+			},
+			replicaSet: newReplicaSet(3, 0, deploymentOwner),
+			want:       true,
+			wantErr:    false,
+		},
+		{
+			name:       "ReplicaSet should be deleted when it is orphaned from a missing Deployment",
+			replicaSet: newReplicaSet(3, 0, nil),
+			want:       true,
+			wantErr:    false,
+		},
+		{
+			name: "ReplicaSet should not be deleted when it is within revisionHistoryLimit",
+			fields: fields{
+				replicaSets: ownedReplicaSets,
+				deployments: []*appsv1.Deployment{newDeployment(4, &limit2)},
+			},
+			replicaSet: newReplicaSet(2, 0, deploymentOwner),
+			want:       false,
+			wantErr:    false,
+		},
+		{
+			name: "ReplicaSet should not be deleted when it is within the default revisionHistoryLimit",
+			fields: fields{
+				replicaSets: ownedReplicaSets,
+				deployments: []*appsv1.Deployment{newDeployment(4, nil)},
+			},
+			replicaSet: newReplicaSet(1, 0, deploymentOwner),
+			want:       false,
+			wantErr:    false,
+		},
+		{
+			name: "active ReplicaSet should not be deleted even if it has no replicas",
+			fields: fields{
+				replicaSets: ownedReplicaSets,
+				deployments: []*appsv1.Deployment{newDeployment(4, &limit0)},
+			},
+			replicaSet: newReplicaSet(4, 0, deploymentOwner),
+			want:       false,
+			wantErr:    false,
+		},
+		{
+			name: "ReplicaSet should not be deleted when it has replicas",
+			fields: fields{
+				replicaSets: ownedReplicaSets,
+				deployments: []*appsv1.Deployment{newDeployment(4, &limit0)},
+			},
+			replicaSet: newReplicaSet(1, 2, deploymentOwner),
+			want:       false,
+			wantErr:    false,
+		},
+		{
+			name: "orphaned ReplicaSet should not be deleted when a Deployment will adopt it",
+			fields: fields{
+				deployments: []*appsv1.Deployment{newDeployment(4, &limit0)},
+			},
+			replicaSet: newReplicaSet(1, 0, nil),
+			want:       false,
+			wantErr:    false,
+		},
+		{
+			name: "ReplicaSet not managed by Deployment should not be deleted",
+			replicaSet: func() *appsv1.ReplicaSet {
+				rs := newReplicaSet(1, 0, nil)
+				rs.Annotations = nil
+				return rs
+			}(),
+			want:    false,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &determiner{
+				replicaSets: tt.fields.replicaSets,
+				deployments: tt.fields.deployments,
+			}
+
+			info := &cliresource.Info{
+				Name:      tt.replicaSet.Name,
+				Namespace: tt.replicaSet.Namespace,
+				Object:    tt.replicaSet,
+			}
+
+			got, err := d.DetermineDeletion(context.Background(), info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Delete != tt.want {
+				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package determiner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+// secretConsumer detects Secrets consumed by a kind of objects and adds them to refs.
+// Cluster-scoped consumers may add Secrets in any namespace.
+type secretConsumer func(ctx context.Context, d *determiner, namespace string, refs references) error
+
+// secretConsumers is the list of consumers used to detect used Secrets.
+var secretConsumers = []secretConsumer{
+	podSpecSecretConsumer,
+	serviceAccountSecretConsumer,
+	ingressSecretConsumer,
+	storageClassSecretConsumer,
+	persistentVolumeSecretConsumer,
+}
+
+// storageClassSecretParameters is the list of StorageClass parameter prefixes naming Secrets used by CSI drivers.
+// Each prefix is followed by "-secret-name" and "-secret-namespace".
+var storageClassSecretParameters = []string{
+	"csi.storage.k8s.io/provisioner",
+	"csi.storage.k8s.io/controller-publish",
+	"csi.storage.k8s.io/node-stage",
+	"csi.storage.k8s.io/node-publish",
+	"csi.storage.k8s.io/controller-expand",
+}
+
+// podSpecSecretConsumer detects Secrets used by Pods and pod templates.
+func podSpecSecretConsumer(_ context.Context, d *determiner, _ string, refs references) error {
+	for _, owner := range d.podSpecOwners() {
+		for _, name := range extractPodSpecReferences(owner.spec).secrets {
+			refs.add(types.NamespacedName{Namespace: owner.ref.Namespace, Name: name}, owner.ref)
+		}
+	}
+
+	return nil
+}
+
+// serviceAccountSecretConsumer detects Secrets listed by ServiceAccounts and
+// service account token Secrets annotated for an existing ServiceAccount.
+func serviceAccountSecretConsumer(ctx context.Context, d *determiner, namespace string, refs references) error {
+	sas, err := d.resourceClient.ListServiceAccounts(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	existingServiceAccounts := make(map[types.NamespacedName]struct{}, len(sas))
+	for _, sa := range sas {
+		existingServiceAccounts[types.NamespacedName{Namespace: sa.Namespace, Name: sa.Name}] = struct{}{}
+
+		for _, secret := range sa.Secrets {
+			refs.add(types.NamespacedName{Namespace: sa.Namespace, Name: secret.Name}, referenceTo(resource.KindServiceAccount, sa))
+		}
+	}
+
+	allSecrets, err := d.resourceClient.ListSecrets(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range allSecrets {
+		if secret.Type != corev1.SecretTypeServiceAccountToken {
+			continue
+		}
+		sa := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Annotations[corev1.ServiceAccountNameKey]}
+		if _, ok := existingServiceAccounts[sa]; ok {
+			refs.add(types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, Reference{
+				Kind:      resource.KindServiceAccount,
+				Namespace: sa.Namespace,
+				Name:      sa.Name,
+			})
+		}
+	}
+
+	return nil
+}
+
+// ingressSecretConsumer detects TLS Secrets used by Ingresses.
+func ingressSecretConsumer(ctx context.Context, d *determiner, namespace string, refs references) error {
+	ingresses, err := d.resourceClient.ListIngresses(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, ing := range ingresses {
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+			refs.add(types.NamespacedName{Namespace: ing.Namespace, Name: tls.SecretName}, referenceTo(resource.KindIngress, ing))
+		}
+	}
+
+	return nil
+}
+
+// storageClassSecretConsumer detects Secrets passed to CSI drivers via StorageClass parameters.
+// Parameters templated with the claim (e.g. ${pvc.namespace}) are resolved against existing PersistentVolumeClaims.
+func storageClassSecretConsumer(ctx context.Context, d *determiner, _ string, refs references) error {
+	scs, err := d.resourceClient.ListStorageClasses(ctx)
+	if err != nil {
+		return clusterScopedListError("storageclasses.storage.k8s.io", err)
+	}
+
+	var claims []*corev1.PersistentVolumeClaim
+
+	for _, sc := range scs {
+		ref := referenceTo(resource.KindStorageClass, sc)
+
+		for _, prefix := range storageClassSecretParameters {
+			name, ok := sc.Parameters[prefix+"-secret-name"]
+			if !ok {
+				continue
+			}
+			namespace := sc.Parameters[prefix+"-secret-namespace"]
+
+			if !strings.Contains(name, "${") && !strings.Contains(namespace, "${") {
+				refs.add(types.NamespacedName{Namespace: namespace, Name: name}, ref)
+				continue
+			}
+
+			if claims == nil {
+				claims, err = d.resourceClient.ListPersistentVolumeClaims(ctx, metav1.NamespaceAll)
+				if err != nil {
+					return clusterScopedListError("persistentvolumeclaims", err)
+				}
+			}
+
+			for _, claim := range claims {
+				if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName != sc.Name {
+					continue
+				}
+				refs.add(types.NamespacedName{
+					Namespace: expandClaimTemplate(namespace, claim),
+					Name:      expandClaimTemplate(name, claim),
+				}, ref)
+			}
+		}
+	}
+
+	return nil
+}
+
+// persistentVolumeSecretConsumer detects Secrets referenced by CSI PersistentVolumes.
+func persistentVolumeSecretConsumer(ctx context.Context, d *determiner, _ string, refs references) error {
+	pvs, err := d.resourceClient.ListPersistentVolumes(ctx)
+	if err != nil {
+		return clusterScopedListError("persistentvolumes", err)
+	}
+
+	for _, pv := range pvs {
+		csi := pv.Spec.CSI
+		if csi == nil {
+			continue
+		}
+
+		for _, ref := range []*corev1.SecretReference{
+			csi.ControllerPublishSecretRef,
+			csi.NodeStageSecretRef,
+			csi.NodePublishSecretRef,
+			csi.ControllerExpandSecretRef,
+		} {
+			if ref == nil {
+				continue
+			}
+			refs.add(types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, referenceTo(resource.KindPersistentVolume, pv))
+		}
+	}
+
+	return nil
+}
+
+// clusterScopedListError names the missing permission if listing the resource in all namespaces is forbidden.
+// Secrets can't be told unused without it, so the error is never ignored.
+func clusterScopedListError(resource string, err error) error {
+	if !apierrors.IsForbidden(err) {
+		return err
+	}
+	return fmt.Errorf("reaping Secrets requires permission to list %s cluster-wide: %w", resource, err)
+}
+
+// expandClaimTemplate expands the parameter template variables CSI external-provisioner supports.
+func expandClaimTemplate(s string, claim *corev1.PersistentVolumeClaim) string {
+	return strings.NewReplacer(
+		"${pvc.name}", claim.Name,
+		"${pvc.namespace}", claim.Namespace,
+		"${pv.name}", claim.Spec.VolumeName,
+	).Replace(s)
+}
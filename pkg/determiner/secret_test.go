@@ -0,0 +1,180 @@
+package determiner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_secretConsumers(t *testing.T) {
+	const (
+		fakeNamespace      = "fake-ns"
+		fakeSecret         = "fake-secret"
+		fakeServiceAccount = "fake-sa"
+		fakeStorageClass   = "fake-sc"
+		fakeClaim          = "fake-pvc"
+	)
+
+	fakeStorageClassName := fakeStorageClass
+
+	tests := []struct {
+		name        string
+		consumer    secretConsumer
+		fakeObjects []runtime.Object
+		fakeErrors  map[string]error // key=Kind
+		want        references
+		wantErr     bool
+	}{
+		{
+			name:     "TLS Secrets referenced by Ingresses should be detected",
+			consumer: ingressSecretConsumer,
+			fakeObjects: []runtime.Object{
+				&networkingv1.Ingress{
+					TypeMeta:   metav1.TypeMeta{Kind: resource.KindIngress},
+					ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace},
+					Spec: networkingv1.IngressSpec{
+						TLS: []networkingv1.IngressTLS{{SecretName: fakeSecret}, {}},
+					},
+				},
+			},
+			want: references{
+				{Namespace: fakeNamespace, Name: fakeSecret}: {{Kind: resource.KindIngress, Namespace: fakeNamespace}},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "service account token Secrets should be detected only when the ServiceAccount exists",
+			consumer: serviceAccountSecretConsumer,
+			fakeObjects: []runtime.Object{
+				&corev1.ServiceAccount{
+					TypeMeta:   metav1.TypeMeta{Kind: resource.KindServiceAccount},
+					ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace, Name: fakeServiceAccount},
+				},
+				&corev1.Secret{
+					TypeMeta: metav1.TypeMeta{Kind: resource.KindSecret},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   fakeNamespace,
+						Name:        fakeSecret,
+						Annotations: map[string]string{corev1.ServiceAccountNameKey: fakeServiceAccount},
+					},
+					Type: corev1.SecretTypeServiceAccountToken,
+				},
+				&corev1.Secret{
+					TypeMeta: metav1.TypeMeta{Kind: resource.KindSecret},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   fakeNamespace,
+						Name:        "fake-orphaned-token",
+						Annotations: map[string]string{corev1.ServiceAccountNameKey: "fake-deleted-sa"},
+					},
+					Type: corev1.SecretTypeServiceAccountToken,
+				},
+			},
+			want: references{
+				{Namespace: fakeNamespace, Name: fakeSecret}: {{Kind: resource.KindServiceAccount, Namespace: fakeNamespace, Name: fakeServiceAccount}},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Secrets in StorageClass parameters should be detected with templates expanded",
+			consumer: storageClassSecretConsumer,
+			fakeObjects: []runtime.Object{
+				&storagev1.StorageClass{
+					TypeMeta:   metav1.TypeMeta{Kind: resource.KindStorageClass},
+					ObjectMeta: metav1.ObjectMeta{Name: fakeStorageClass},
+					Parameters: map[string]string{
+						"csi.storage.k8s.io/provisioner-secret-name":       fakeSecret,
+						"csi.storage.k8s.io/provisioner-secret-namespace":  fakeNamespace,
+						"csi.storage.k8s.io/node-publish-secret-name":      "${pvc.name}-secret",
+						"csi.storage.k8s.io/node-publish-secret-namespace": "${pvc.namespace}",
+					},
+				},
+				&corev1.PersistentVolumeClaim{
+					TypeMeta:   metav1.TypeMeta{Kind: resource.KindPersistentVolumeClaim},
+					ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace, Name: fakeClaim},
+					Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &fakeStorageClassName},
+				},
+			},
+			want: references{
+				{Namespace: fakeNamespace, Name: fakeSecret}:            {{Kind: resource.KindStorageClass, Name: fakeStorageClass}},
+				{Namespace: fakeNamespace, Name: fakeClaim + "-secret"}: {{Kind: resource.KindStorageClass, Name: fakeStorageClass}},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Secrets referenced by CSI PersistentVolumes should be detected",
+			consumer: persistentVolumeSecretConsumer,
+			fakeObjects: []runtime.Object{
+				&corev1.PersistentVolume{
+					TypeMeta: metav1.TypeMeta{Kind: resource.KindPersistentVolume},
+					Spec: corev1.PersistentVolumeSpec{
+						PersistentVolumeSource: corev1.PersistentVolumeSource{
+							CSI: &corev1.CSIPersistentVolumeSource{
+								NodePublishSecretRef:      &corev1.SecretReference{Namespace: fakeNamespace, Name: fakeSecret},
+								ControllerExpandSecretRef: &corev1.SecretReference{Namespace: fakeNamespace, Name: fakeSecret + "-expand"},
+							},
+						},
+					},
+				},
+			},
+			want: references{
+				{Namespace: fakeNamespace, Name: fakeSecret}:             {{Kind: resource.KindPersistentVolume}},
+				{Namespace: fakeNamespace, Name: fakeSecret + "-expand"}: {{Kind: resource.KindPersistentVolume}},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "forbidden listing of PersistentVolumes should fail",
+			consumer: persistentVolumeSecretConsumer,
+			fakeErrors: map[string]error{
+				resource.KindPersistentVolume: apierrors.NewForbidden(schema.GroupResource{Resource: "persistentvolumes"}, "", errors.New("forbidden")),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := resource.NewFakeClient(tt.fakeObjects...)
+			if err != nil {
+				t.Errorf("failed to construct fake resource client")
+				return
+			}
+
+			for kind, err := range tt.fakeErrors {
+				c.SetError(kind, err)
+			}
+
+			d := &determiner{
+				resourceClient: c,
+			}
+
+			got := make(references)
+			if err := tt.consumer(context.Background(), d, fakeNamespace, got); (err != nil) != tt.wantErr {
+				t.Errorf("secretConsumer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
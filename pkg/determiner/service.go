@@ -0,0 +1,71 @@
+package determiner
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+// DefaultServiceEmptyFor is the default minimum period for which the EndpointSlices of a Service to be reaped
+// have been empty.
+const DefaultServiceEmptyFor = time.Hour
+
+func (d *determiner) determineDeletionService(info *cliresource.Info) (*Decision, error) {
+	svc, err := resource.ObjectToService(info.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		return keepDecision(nil, "ExternalName Service"), nil
+	}
+	if len(svc.Spec.Selector) == 0 {
+		// Endpoints of a Service without selector are managed manually.
+		return keepDecision(nil, "without selector"), nil
+	}
+
+	if pods := d.selectedPods(svc); len(pods) > 0 {
+		return keepDecision(pods, "selecting Pods"), nil
+	}
+
+	// The EndpointSlices can have endpoints which are not Pods selected by the Service, e.g. the ones being terminated.
+	emptySince := svc.CreationTimestamp.Time
+	for _, slice := range d.endpointSlices {
+		if slice.Namespace != svc.Namespace || slice.ServiceName() != svc.Name {
+			continue
+		}
+		if len(slice.Endpoints) > 0 {
+			return keepDecision([]Reference{referenceTo(resource.KindEndpointSlice, slice)}, "EndpointSlices have endpoints"), nil
+		}
+		if t := slice.LastChangeTime(); t.After(emptySince) {
+			emptySince = t
+		}
+	}
+
+	if d.now().Sub(emptySince) < d.serviceEmptyFor {
+		return keepDecision(nil, "EndpointSlices empty for less than %s", d.serviceEmptyFor), nil
+	}
+	return deleteDecision("not selecting any Pods and EndpointSlices empty for %s or longer", d.serviceEmptyFor), nil
+}
+
+// selectedPods returns the Pods selected by the Service.
+func (d *determiner) selectedPods(svc *corev1.Service) []Reference {
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+
+	var pods []Reference
+
+	for _, pod := range d.pods {
+		if pod.Namespace != svc.Namespace {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			pods = append(pods, referenceTo(resource.KindPod, pod))
+		}
+	}
+
+	return pods
+}
@@ -0,0 +1,190 @@
+package determiner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_determiner_DetermineDeletion_Service(t *testing.T) {
+	const (
+		fakeNamespace1  = "fake-ns1"
+		fakeNamespace2  = "fake-ns2"
+		fakeService     = "fake-svc"
+		fakeLabelKey    = "fake-label-key"
+		fakeLabelValue  = "fake-label-value"
+		fakeEmptyPeriod = time.Hour
+	)
+
+	fakeTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newService := func(typ corev1.ServiceType, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind: resource.KindService,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fakeService,
+				Namespace:         fakeNamespace1,
+				CreationTimestamp: metav1.NewTime(fakeTime.Add(-24 * time.Hour)),
+			},
+			Spec: corev1.ServiceSpec{
+				Type:     typ,
+				Selector: selector,
+			},
+		}
+	}
+
+	newEndpointSlice := func(lastChange time.Time, addresses ...string) *resource.EndpointSlice {
+		slice := &resource.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fakeService + "-abcde",
+				Namespace:   fakeNamespace1,
+				Labels:      map[string]string{resource.LabelServiceName: fakeService},
+				Annotations: map[string]string{resource.AnnotationLastChangeTriggerTime: lastChange.Format(time.RFC3339)},
+			},
+		}
+		for _, addr := range addresses {
+			slice.Endpoints = append(slice.Endpoints, resource.Endpoint{Addresses: []string{addr}})
+		}
+		return slice
+	}
+
+	fakeSelector := map[string]string{fakeLabelKey: fakeLabelValue}
+
+	type fields struct {
+		pods           []*corev1.Pod
+		endpointSlices []*resource.EndpointSlice
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		service *corev1.Service
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Service should be deleted when it selects no Pods and its EndpointSlices have been empty for a while",
+			fields: fields{
+				endpointSlices: []*resource.EndpointSlice{
+					newEndpointSlice(fakeTime.Add(-2 * fakeEmptyPeriod)),
+				},
+			},
+			service: newService(corev1.ServiceTypeClusterIP, fakeSelector),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Service should be deleted when it selects no Pods and has no EndpointSlices",
+			fields: fields{
+				endpointSlices: []*resource.EndpointSlice{},
+			},
+			service: newService(corev1.ServiceTypeClusterIP, fakeSelector),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Service should be deleted when only Pods in another namespace match it",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: fakeNamespace2,
+							Labels:    fakeSelector,
+						},
+					},
+				},
+			},
+			service: newService(corev1.ServiceTypeClusterIP, fakeSelector),
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Service should not be deleted when it selects Pods",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: fakeNamespace1,
+							Labels:    fakeSelector,
+						},
+					},
+				},
+			},
+			service: newService(corev1.ServiceTypeClusterIP, fakeSelector),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Service should not be deleted when its EndpointSlices have endpoints",
+			fields: fields{
+				endpointSlices: []*resource.EndpointSlice{
+					newEndpointSlice(fakeTime.Add(-2*fakeEmptyPeriod), "10.0.0.1"),
+				},
+			},
+			service: newService(corev1.ServiceTypeClusterIP, fakeSelector),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Service should not be deleted when its EndpointSlices have just become empty",
+			fields: fields{
+				endpointSlices: []*resource.EndpointSlice{
+					newEndpointSlice(fakeTime.Add(-fakeEmptyPeriod / 2)),
+				},
+			},
+			service: newService(corev1.ServiceTypeClusterIP, fakeSelector),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "ExternalName Service should not be deleted",
+			service: newService(corev1.ServiceTypeExternalName, nil),
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "Service without selector should not be deleted",
+			service: newService(corev1.ServiceTypeClusterIP, nil),
+			want:    false,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &determiner{
+				serviceEmptyFor: fakeEmptyPeriod,
+				clock:           func() time.Time { return fakeTime },
+				pods:            tt.fields.pods,
+				endpointSlices:  tt.fields.endpointSlices,
+			}
+
+			info := &cliresource.Info{
+				Name:      tt.service.Name,
+				Namespace: tt.service.Namespace,
+				Object:    tt.service,
+			}
+
+			got, err := d.DetermineDeletion(context.Background(), info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Delete != tt.want {
+				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
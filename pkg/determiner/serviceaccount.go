@@ -0,0 +1,79 @@
+package determiner
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+// defaultServiceAccount is the ServiceAccount created in every namespace and used by Pods without serviceAccountName.
+const defaultServiceAccount = "default"
+
+func (d *determiner) determineDeletionServiceAccount(info *cliresource.Info) (*Decision, error) {
+	if info.Name == defaultServiceAccount {
+		return keepDecision(nil, "default ServiceAccount"), nil
+	}
+
+	if refs, ok := d.usedServiceAccounts[types.NamespacedName{Namespace: info.Namespace, Name: info.Name}]; ok {
+		return keepDecision(refs, "referenced by Pods, workloads, RoleBindings, or ClusterRoleBindings"), nil
+	}
+	return deleteDecision("not referenced by any Pods, workloads, RoleBindings, or ClusterRoleBindings"), nil
+}
+
+func (d *determiner) detectUsedServiceAccounts() references {
+	usedServiceAccounts := make(references)
+
+	for _, owner := range d.podSpecOwners() {
+		name := podSpecServiceAccount(owner.spec)
+		usedServiceAccounts.add(types.NamespacedName{Namespace: owner.ref.Namespace, Name: name}, owner.ref)
+	}
+
+	for _, rb := range d.roleBindings {
+		for _, sa := range serviceAccountSubjects(rb.Subjects, rb.Namespace) {
+			usedServiceAccounts.add(sa, referenceTo(resource.KindRoleBinding, rb))
+		}
+	}
+
+	for _, crb := range d.clusterRoleBindings {
+		for _, sa := range serviceAccountSubjects(crb.Subjects, "") {
+			usedServiceAccounts.add(sa, referenceTo(resource.KindClusterRoleBinding, crb))
+		}
+	}
+
+	return usedServiceAccounts
+}
+
+// podSpecServiceAccount returns the name of the ServiceAccount the PodSpec runs as.
+func podSpecServiceAccount(spec *corev1.PodSpec) string {
+	switch {
+	case spec.ServiceAccountName != "":
+		return spec.ServiceAccountName
+	case spec.DeprecatedServiceAccount != "":
+		return spec.DeprecatedServiceAccount
+	default:
+		return defaultServiceAccount
+	}
+}
+
+// serviceAccountSubjects returns the ServiceAccounts in the subjects of a RoleBinding or a ClusterRoleBinding.
+// Subjects without namespace are regarded as in defaultNamespace.
+func serviceAccountSubjects(subjects []rbacv1.Subject, defaultNamespace string) []types.NamespacedName {
+	var sas []types.NamespacedName
+
+	for _, subject := range subjects {
+		if subject.Kind != rbacv1.ServiceAccountKind {
+			continue
+		}
+
+		namespace := subject.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		sas = append(sas, types.NamespacedName{Namespace: namespace, Name: subject.Name})
+	}
+
+	return sas
+}
@@ -0,0 +1,221 @@
+package determiner
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cliresource "k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/micnncim/kubectl-reap/pkg/resource"
+)
+
+func Test_determiner_DetermineDeletion_ServiceAccount(t *testing.T) {
+	const (
+		fakeNamespace1         = "fake-ns1"
+		fakeNamespace2         = "fake-ns2"
+		fakeServiceAccount     = "fake-sa"
+		fakeRoleBinding        = "fake-rb"
+		fakeClusterRoleBinding = "fake-crb"
+	)
+
+	newServiceAccount := func(name string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{
+				Kind: resource.KindServiceAccount,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: fakeNamespace1,
+			},
+		}
+	}
+
+	serviceAccountSubject := func(namespace string) rbacv1.Subject {
+		return rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: namespace,
+			Name:      fakeServiceAccount,
+		}
+	}
+
+	type fields struct {
+		pods                []*corev1.Pod
+		deployments         []*appsv1.Deployment
+		roleBindings        []*rbacv1.RoleBinding
+		clusterRoleBindings []*rbacv1.ClusterRoleBinding
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		serviceAccount *corev1.ServiceAccount
+		want           bool
+		wantErr        bool
+	}{
+		{
+			name:           "ServiceAccount should be deleted when it is not referenced",
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           true,
+			wantErr:        false,
+		},
+		{
+			name: "ServiceAccount should be deleted when only Pods in another namespace use it",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace2},
+						Spec:       corev1.PodSpec{ServiceAccountName: fakeServiceAccount},
+					},
+				},
+			},
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           true,
+			wantErr:        false,
+		},
+		{
+			name: "ServiceAccount should be deleted when RoleBindings bind only subjects of other kinds",
+			fields: fields{
+				roleBindings: []*rbacv1.RoleBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace1, Name: fakeRoleBinding},
+						Subjects: []rbacv1.Subject{
+							{Kind: rbacv1.UserKind, Name: fakeServiceAccount},
+						},
+					},
+				},
+			},
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           true,
+			wantErr:        false,
+		},
+		{
+			name: "ServiceAccount should not be deleted when a Pod uses it",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace1},
+						Spec:       corev1.PodSpec{ServiceAccountName: fakeServiceAccount},
+					},
+				},
+			},
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name: "ServiceAccount should not be deleted when a Pod uses it by the deprecated field",
+			fields: fields{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace1},
+						Spec:       corev1.PodSpec{DeprecatedServiceAccount: fakeServiceAccount},
+					},
+				},
+			},
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name: "ServiceAccount should not be deleted when a Deployment uses it",
+			fields: fields{
+				deployments: []*appsv1.Deployment{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace1},
+						Spec: appsv1.DeploymentSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{ServiceAccountName: fakeServiceAccount},
+							},
+						},
+					},
+				},
+			},
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name: "ServiceAccount should not be deleted when a RoleBinding in the same namespace binds it without namespace",
+			fields: fields{
+				roleBindings: []*rbacv1.RoleBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace1, Name: fakeRoleBinding},
+						Subjects:   []rbacv1.Subject{serviceAccountSubject("")},
+					},
+				},
+			},
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name: "ServiceAccount should not be deleted when a RoleBinding in another namespace binds it",
+			fields: fields{
+				roleBindings: []*rbacv1.RoleBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace2, Name: fakeRoleBinding},
+						Subjects:   []rbacv1.Subject{serviceAccountSubject(fakeNamespace1)},
+					},
+				},
+			},
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name: "ServiceAccount should not be deleted when a ClusterRoleBinding binds it",
+			fields: fields{
+				clusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: fakeClusterRoleBinding},
+						Subjects:   []rbacv1.Subject{serviceAccountSubject(fakeNamespace1)},
+					},
+				},
+			},
+			serviceAccount: newServiceAccount(fakeServiceAccount),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name:           "default ServiceAccount should not be deleted",
+			serviceAccount: newServiceAccount("default"),
+			want:           false,
+			wantErr:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &determiner{
+				pods:                tt.fields.pods,
+				deployments:         tt.fields.deployments,
+				roleBindings:        tt.fields.roleBindings,
+				clusterRoleBindings: tt.fields.clusterRoleBindings,
+			}
+			d.usedServiceAccounts = d.detectUsedServiceAccounts()
+
+			info := &cliresource.Info{
+				Name:      tt.serviceAccount.Name,
+				Namespace: tt.serviceAccount.Namespace,
+				Object:    tt.serviceAccount,
+			}
+
+			got, err := d.DetermineDeletion(context.Background(), info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("determiner.DetermineDeletion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Delete != tt.want {
+				t.Errorf("determiner.DetermineDeletion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
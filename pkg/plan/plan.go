@@ -0,0 +1,60 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Plan is the list of resources to be deleted, which is applied later.
+type Plan struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a resource to be deleted.
+type Entry struct {
+	APIVersion      string `json:"apiVersion"`
+	Kind            string `json:"kind"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion"` // the resource is not deleted if it has changed since planning
+	Reason          string `json:"reason,omitempty"`
+}
+
+func New() *Plan {
+	return &Plan{
+		Entries: []Entry{},
+	}
+}
+
+// Add adds an entry to the plan.
+func (p *Plan) Add(e Entry) {
+	p.Entries = append(p.Entries, e)
+}
+
+// Write writes the plan to w in YAML.
+func (p *Plan) Write(w io.Writer) error {
+	b, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// Read reads a plan written in YAML or JSON from r.
+func Read(r io.Reader) (*Plan, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := New()
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,98 @@
+package plan
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPlan_Write(t *testing.T) {
+	p := New()
+	p.Add(Entry{
+		APIVersion:      "v1",
+		Kind:            "ConfigMap",
+		Namespace:       "fake-ns",
+		Name:            "fake-cm",
+		ResourceVersion: "1",
+		Reason:          "not referenced by any Pods or workloads",
+	})
+	p.Add(Entry{
+		APIVersion:      "v1",
+		Kind:            "PersistentVolume",
+		Name:            "fake-pv",
+		ResourceVersion: "2",
+	})
+
+	want := `entries:
+- apiVersion: v1
+  kind: ConfigMap
+  name: fake-cm
+  namespace: fake-ns
+  reason: not referenced by any Pods or workloads
+  resourceVersion: "1"
+- apiVersion: v1
+  kind: PersistentVolume
+  name: fake-pv
+  resourceVersion: "2"
+`
+
+	var b bytes.Buffer
+	if err := p.Write(&b); err != nil {
+		t.Fatalf("Plan.Write() error = %v", err)
+	}
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	got, err := Read(&b)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if diff := cmp.Diff(p, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    *Plan
+		wantErr bool
+	}{
+		{
+			name: "plan in JSON should be read",
+			in:   `{"entries":[{"apiVersion":"v1","kind":"Secret","namespace":"fake-ns","name":"fake-secret","resourceVersion":"3"}]}`,
+			want: &Plan{
+				Entries: []Entry{
+					{APIVersion: "v1", Kind: "Secret", Namespace: "fake-ns", Name: "fake-secret", ResourceVersion: "3"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "unknown fields should be rejected",
+			in:      `{"entries":[{"kind":"Secret","uid":"fake-uid"}]}`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Read(strings.NewReader(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,16 +1,179 @@
 package prompt
 
-import "github.com/AlecAivazis/survey/v2"
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
 
-func Confirm(message string) bool {
-	c := &survey.Confirm{
-		Message: message,
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// Choices of ConfirmBatch.
+const (
+	ChoiceYesToAll = "Yes to all"
+	ChoiceNo       = "No"
+	ChoiceSelect   = "Select"
+)
+
+// Item is a resource asked whether to be deleted.
+type Item struct {
+	Kind      string
+	Namespace string // empty if the resource is cluster-scoped
+	Name      string
+	Reason    string // why the resource is to be deleted
+}
+
+type options struct {
+	stdio terminal.Stdio
+}
+
+// Option configures prompts.
+type Option func(*options)
+
+// WithStdio makes prompts interact with the given files instead of the standard input, output and error.
+func WithStdio(in terminal.FileReader, out terminal.FileWriter, err io.Writer) Option {
+	return func(o *options) {
+		o.stdio = terminal.Stdio{In: in, Out: out, Err: err}
+	}
+}
+
+func askOpts(opts []Option) []survey.AskOpt {
+	o := &options{
+		stdio: terminal.Stdio{In: os.Stdin, Out: os.Stdout, Err: os.Stderr},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return []survey.AskOpt{
+		survey.WithStdio(o.stdio.In, o.stdio.Out, o.stdio.Err),
+	}
+}
+
+func (i Item) String() string {
+	if i.Namespace == "" {
+		return fmt.Sprintf("%s/%s", strings.ToLower(i.Kind), i.Name)
+	}
+	return fmt.Sprintf("%s/%s (%s)", strings.ToLower(i.Kind), i.Name, i.Namespace)
+}
+
+// ConfirmBatch shows the items grouped by namespace and kind, and asks once whether all of them can be deleted.
+// Choosing to select them asks which of them can be deleted. It returns the indices of the items which can be deleted.
+func ConfirmBatch(items []Item, opts ...Option) []int {
+	if len(items) == 0 {
+		return nil
+	}
+
+	s := &survey.Select{
+		Message: fmt.Sprintf("%s\nAre you sure to delete the %d resource(s)?", Summarize(items), len(items)),
+		Options: []string{ChoiceYesToAll, ChoiceNo, ChoiceSelect},
+		Default: ChoiceNo,
+	}
+
+	var choice string
+	if err := survey.AskOne(s, &choice, askOpts(opts)...); err != nil {
+		return nil
+	}
+
+	switch choice {
+	case ChoiceYesToAll:
+		all := make([]int, 0, len(items))
+		for i := range items {
+			all = append(all, i)
+		}
+		return all
+	case ChoiceSelect:
+		return multiSelect("Select resources to delete:", items, false, opts)
+	default:
+		return nil
+	}
+}
+
+// Pick shows the items with their reasons as a filterable list whose items are all checked, and asks which of them
+// can be deleted by unchecking the ones which must stay. It returns the indices of the items which can be deleted.
+func Pick(items []Item, opts ...Option) []int {
+	if len(items) == 0 {
+		return nil
+	}
+
+	return multiSelect("Uncheck resources to keep, and delete the others:", items, true, opts)
+}
+
+func multiSelect(message string, items []Item, checked bool, opts []Option) []int {
+	width := 0
+	for _, item := range items {
+		if w := len(item.String()); w > width {
+			width = w
+		}
+	}
+
+	// The reason column is aligned so that the list can be filtered by reasons as well as names.
+	options := make([]string, 0, len(items))
+	for _, item := range items {
+		options = append(options, strings.TrimSpace(fmt.Sprintf("%-*s  %s", width, item.String(), item.Reason)))
+	}
+
+	m := &survey.MultiSelect{
+		Message:  message,
+		Options:  options,
+		PageSize: 20,
+	}
+	if checked {
+		all := make([]int, 0, len(items))
+		for i := range items {
+			all = append(all, i)
+		}
+		m.Default = all
+	}
+
+	var selected []int
+	if err := survey.AskOne(m, &selected, askOpts(opts)...); err != nil {
+		return nil
+	}
+	sort.Ints(selected)
+
+	return selected
+}
+
+// Summarize returns the list of the items grouped by namespace and kind.
+func Summarize(items []Item) string {
+	type group struct {
+		namespace string
+		kind      string
+	}
+
+	names := make(map[group][]string)
+	for _, item := range items {
+		g := group{namespace: item.Namespace, kind: item.Kind}
+		names[g] = append(names[g], item.Name)
+	}
+
+	groups := make([]group, 0, len(names))
+	for g := range names {
+		groups = append(groups, g)
 	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].namespace != groups[j].namespace {
+			return groups[i].namespace < groups[j].namespace
+		}
+		return groups[i].kind < groups[j].kind
+	})
 
-	var ans bool
-	if err := survey.AskOne(c, &ans); err != nil {
-		return false
+	b := strings.Builder{}
+	for _, g := range groups {
+		if g.namespace == "" {
+			fmt.Fprintf(&b, "%s (cluster-scoped, %d):\n", g.kind, len(names[g]))
+		} else {
+			fmt.Fprintf(&b, "%s in %s (%d):\n", g.kind, g.namespace, len(names[g]))
+		}
+		sort.Strings(names[g])
+		for _, name := range names[g] {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
 	}
 
-	return ans
+	return b.String()
 }
@@ -0,0 +1,145 @@
+package prompt
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	keyArrowUp   = "\x1b[A"
+	keyArrowDown = "\x1b[B"
+	keySpace     = " "
+	keyEnter     = "\r"
+)
+
+var fakeItems = []Item{
+	{Kind: "ConfigMap", Namespace: "fake-ns", Name: "fake-cm-1", Reason: "not referenced by any Pods or workloads"},
+	{Kind: "ConfigMap", Namespace: "fake-ns", Name: "fake-cm-2", Reason: "not referenced by any Pods or workloads"},
+	{Kind: "Pod", Namespace: "fake-ns", Name: "fake-pod", Reason: "phase is Evicted"},
+}
+
+func TestConfirmBatch(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want []int
+	}{
+		{
+			name: "all items should be confirmed with yes to all",
+			keys: keyArrowUp + keyEnter,
+			want: []int{0, 1, 2},
+		},
+		{
+			name: "no items should be confirmed by default",
+			keys: keyEnter,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ConfirmBatch(fakeItems, fakeTerminal(t, tt.keys)...)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want []int
+	}{
+		{
+			name: "all items should be picked by default",
+			keys: keyEnter,
+			want: []int{0, 1, 2},
+		},
+		{
+			name: "unchecked item should not be picked",
+			keys: keyArrowDown + keySpace + keyEnter,
+			want: []int{0, 2},
+		},
+		{
+			name: "item filtered by name should be unchecked",
+			keys: "cm-2" + keySpace + keyEnter,
+			want: []int{0, 2},
+		},
+		{
+			name: "item filtered by reason should be unchecked",
+			keys: "evicted" + keySpace + keyEnter,
+			want: []int{0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Pick(fakeItems, fakeTerminal(t, tt.keys)...)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeTerminal returns the options to make a prompt read the keys from a pipe and write to a temporary file.
+// The keys must be answered by a single prompt since the prompt reads all of them at once.
+func fakeTerminal(t *testing.T, keys string) []Option {
+	t.Helper()
+
+	in, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	if _, err := w.WriteString(keys); err != nil {
+		t.Fatalf("failed to write keys: %v", err)
+	}
+	w.Close()
+
+	out, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("failed to create output: %v", err)
+	}
+
+	t.Cleanup(func() {
+		in.Close()
+		out.Close()
+	})
+
+	return []Option{WithStdio(in, out, out)}
+}
+
+func TestSummarize(t *testing.T) {
+	items := []Item{
+		{Kind: "Secret", Namespace: "fake-ns-2", Name: "fake-secret"},
+		{Kind: "ConfigMap", Namespace: "fake-ns-1", Name: "fake-cm-2"},
+		{Kind: "PersistentVolume", Name: "fake-pv"},
+		{Kind: "ConfigMap", Namespace: "fake-ns-1", Name: "fake-cm-1"},
+		{Kind: "Secret", Namespace: "fake-ns-1", Name: "fake-secret"},
+	}
+
+	want := `PersistentVolume (cluster-scoped, 1):
+  fake-pv
+ConfigMap in fake-ns-1 (2):
+  fake-cm-1
+  fake-cm-2
+Secret in fake-ns-1 (1):
+  fake-secret
+Secret in fake-ns-2 (1):
+  fake-secret
+`
+
+	if diff := cmp.Diff(want, Summarize(items)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
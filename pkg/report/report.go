@@ -0,0 +1,129 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Format is the format of a Report.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Status is the outcome of reaping a resource.
+type Status string
+
+const (
+	// StatusCandidate is used for resources which should be deleted but are not deleted because of client-side dry-run,
+	// or because they're written to a plan.
+	StatusCandidate Status = "candidate"
+	// StatusDeleted is used for deleted resources, including the ones deleted as server-side dry-run.
+	StatusDeleted Status = "deleted"
+	// StatusSkipped is used for resources which should not be deleted or are declined in interactive mode.
+	StatusSkipped Status = "skipped"
+	// StatusProtected is used for resources which should be deleted but are protected.
+	StatusProtected Status = "protected"
+	// StatusFailed is used for resources which failed to be deleted.
+	StatusFailed Status = "failed"
+)
+
+// Report is the outcome of reaping resources.
+type Report struct {
+	DryRun  bool                `json:"dryRun"`
+	Entries []Entry             `json:"entries"`
+	Summary map[string]*Summary `json:"summary"` // key=kind
+}
+
+// Entry is the outcome of reaping a resource.
+type Entry struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace,omitempty"`
+	Name       string   `json:"name"`
+	Status     Status   `json:"status"`
+	Reason     string   `json:"reason,omitempty"`
+	References []string `json:"references,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	Backup     string   `json:"backup,omitempty"` // path to the manifest backed up before deletion
+}
+
+// Summary is the number of resources of a kind for each status.
+type Summary struct {
+	Candidates int `json:"candidates"`
+	Deleted    int `json:"deleted"`
+	Skipped    int `json:"skipped"`
+	Protected  int `json:"protected"`
+	Failed     int `json:"failed"`
+}
+
+// ParseFormat parses a format of Report.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatJSON, FormatYAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s (one of: json|yaml)", s)
+	}
+}
+
+func New(dryRun bool) *Report {
+	return &Report{
+		DryRun:  dryRun,
+		Entries: []Entry{},
+		Summary: make(map[string]*Summary),
+	}
+}
+
+// Add adds an entry to the report and counts it in the summary of its kind.
+func (r *Report) Add(e Entry) {
+	r.Entries = append(r.Entries, e)
+
+	s, ok := r.Summary[e.Kind]
+	if !ok {
+		s = &Summary{}
+		r.Summary[e.Kind] = s
+	}
+
+	switch e.Status {
+	case StatusCandidate:
+		s.Candidates++
+	case StatusDeleted:
+		s.Deleted++
+	case StatusSkipped:
+		s.Skipped++
+	case StatusProtected:
+		s.Protected++
+	case StatusFailed:
+		s.Failed++
+	}
+}
+
+// Write writes the report to w in the given format.
+func (r *Report) Write(w io.Writer, format Format) error {
+	var (
+		b   []byte
+		err error
+	)
+
+	switch format {
+	case FormatJSON:
+		b, err = json.MarshalIndent(r, "", "  ")
+		b = append(b, '\n')
+	case FormatYAML:
+		b, err = yaml.Marshal(r)
+	default:
+		return fmt.Errorf("unsupported report format: %s", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
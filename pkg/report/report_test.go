@@ -0,0 +1,116 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReport_Add(t *testing.T) {
+	r := New(false)
+
+	r.Add(Entry{APIVersion: "v1", Kind: "ConfigMap", Namespace: "fake-ns", Name: "fake-cm1", Status: StatusDeleted})
+	r.Add(Entry{APIVersion: "v1", Kind: "ConfigMap", Namespace: "fake-ns", Name: "fake-cm2", Status: StatusSkipped})
+	r.Add(Entry{APIVersion: "v1", Kind: "ConfigMap", Namespace: "fake-ns", Name: "fake-cm3", Status: StatusSkipped})
+	r.Add(Entry{APIVersion: "v1", Kind: "Secret", Namespace: "fake-ns", Name: "fake-secret", Status: StatusFailed, Error: "forbidden"})
+
+	want := map[string]*Summary{
+		"ConfigMap": {Deleted: 1, Skipped: 2},
+		"Secret":    {Failed: 1},
+	}
+
+	if diff := cmp.Diff(want, r.Summary); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	r := New(true)
+	r.Add(Entry{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  "fake-ns",
+		Name:       "fake-cm",
+		Status:     StatusCandidate,
+		Reason:     "not referenced by any Pods or workloads",
+	})
+
+	tests := []struct {
+		name    string
+		format  Format
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "report should be written in JSON",
+			format: FormatJSON,
+			want: `{
+  "dryRun": true,
+  "entries": [
+    {
+      "apiVersion": "v1",
+      "kind": "ConfigMap",
+      "namespace": "fake-ns",
+      "name": "fake-cm",
+      "status": "candidate",
+      "reason": "not referenced by any Pods or workloads"
+    }
+  ],
+  "summary": {
+    "ConfigMap": {
+      "candidates": 1,
+      "deleted": 0,
+      "skipped": 0,
+      "protected": 0,
+      "failed": 0
+    }
+  }
+}
+`,
+			wantErr: false,
+		},
+		{
+			name:   "report should be written in YAML",
+			format: FormatYAML,
+			want: `dryRun: true
+entries:
+- apiVersion: v1
+  kind: ConfigMap
+  name: fake-cm
+  namespace: fake-ns
+  reason: not referenced by any Pods or workloads
+  status: candidate
+summary:
+  ConfigMap:
+    candidates: 1
+    deleted: 0
+    failed: 0
+    protected: 0
+    skipped: 0
+`,
+			wantErr: false,
+		},
+		{
+			name:    "unsupported format should cause error",
+			format:  Format("xml"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+			if err := r.Write(&b, tt.format); (err != nil) != tt.wantErr {
+				t.Errorf("Report.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,23 +21,40 @@ import (
 type Client interface {
 	ListPods(ctx context.Context, namespace string) ([]*corev1.Pod, error)
 	ListReplicaSets(ctx context.Context, namespace string) ([]*appsv1.ReplicaSet, error)
+	ListDeployments(ctx context.Context, namespace string) ([]*appsv1.Deployment, error)
+	ListStatefulSets(ctx context.Context, namespace string) ([]*appsv1.StatefulSet, error)
+	ListDaemonSets(ctx context.Context, namespace string) ([]*appsv1.DaemonSet, error)
+	ListJobs(ctx context.Context, namespace string) ([]*batchv1.Job, error)
+	ListCronJobs(ctx context.Context, namespace string) ([]*batchv1beta1.CronJob, error)
+	ListNamespaces(ctx context.Context) ([]*corev1.Namespace, error)
 	ListServiceAccounts(ctx context.Context, namespace string) ([]*corev1.ServiceAccount, error)
+	ListSecrets(ctx context.Context, namespace string) ([]*corev1.Secret, error)
+	ListIngresses(ctx context.Context, namespace string) ([]*Ingress, error)
+	ListPersistentVolumes(ctx context.Context) ([]*corev1.PersistentVolume, error)
 	ListPersistentVolumeClaims(ctx context.Context, namespace string) ([]*corev1.PersistentVolumeClaim, error)
+	ListStorageClasses(ctx context.Context) ([]*storagev1.StorageClass, error)
+	ListEndpointSlices(ctx context.Context, namespace string) ([]*EndpointSlice, error)
+	ListRoles(ctx context.Context, namespace string) ([]*rbacv1.Role, error)
+	ListClusterRoles(ctx context.Context) ([]*rbacv1.ClusterRole, error)
+	ListRoleBindings(ctx context.Context, namespace string) ([]*rbacv1.RoleBinding, error)
+	ListClusterRoleBindings(ctx context.Context) ([]*rbacv1.ClusterRoleBinding, error)
 	GetUnstructured(ctx context.Context, apiVersion, kind, name, namespace string) (*unstructured.Unstructured, error)
 }
 
 type client struct {
 	clientset     kubernetes.Interface
 	dynamicClient dynamic.Interface
+	mapper        apimeta.RESTMapper
 }
 
 // Guarantee *client implements Client.
 var _ Client = (*client)(nil)
 
-func NewClient(clientset kubernetes.Interface, dynamicClient dynamic.Interface) Client {
+func NewClient(clientset kubernetes.Interface, dynamicClient dynamic.Interface, mapper apimeta.RESTMapper) Client {
 	return &client{
 		clientset:     clientset,
 		dynamicClient: dynamicClient,
+		mapper:        mapper,
 	}
 }
 
@@ -65,6 +86,106 @@ func (c *client) ListReplicaSets(ctx context.Context, namespace string) ([]*apps
 	return rss, nil
 }
 
+func (c *client) ListDeployments(ctx context.Context, namespace string) ([]*appsv1.Deployment, error) {
+	deployList, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	deploys := make([]*appsv1.Deployment, 0, len(deployList.Items))
+	for i := range deployList.Items {
+		deploys = append(deploys, &deployList.Items[i])
+	}
+
+	return deploys, nil
+}
+
+func (c *client) ListStatefulSets(ctx context.Context, namespace string) ([]*appsv1.StatefulSet, error) {
+	stsList, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	stss := make([]*appsv1.StatefulSet, 0, len(stsList.Items))
+	for i := range stsList.Items {
+		stss = append(stss, &stsList.Items[i])
+	}
+
+	return stss, nil
+}
+
+func (c *client) ListDaemonSets(ctx context.Context, namespace string) ([]*appsv1.DaemonSet, error) {
+	dsList, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	dss := make([]*appsv1.DaemonSet, 0, len(dsList.Items))
+	for i := range dsList.Items {
+		dss = append(dss, &dsList.Items[i])
+	}
+
+	return dss, nil
+}
+
+func (c *client) ListJobs(ctx context.Context, namespace string) ([]*batchv1.Job, error) {
+	jobList, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*batchv1.Job, 0, len(jobList.Items))
+	for i := range jobList.Items {
+		jobs = append(jobs, &jobList.Items[i])
+	}
+
+	return jobs, nil
+}
+
+// ListCronJobs lists CronJobs in batch/v1, or in batch/v1beta1 on clusters which don't serve batch/v1.
+// CronJobs of both versions are decoded into batch/v1beta1 CronJobs since they share the schema.
+// It returns nil without error if CronJobs are not served at all.
+func (c *client) ListCronJobs(ctx context.Context, namespace string) ([]*batchv1beta1.CronJob, error) {
+	mapping, err := c.mapper.RESTMapping(schema.GroupKind{Group: batchv1beta1.GroupName, Kind: KindCronJob}, "v1", "v1beta1")
+	switch {
+	case err == nil:
+	case apimeta.IsNoMatchError(err):
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	cjList, err := c.dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	cjs := make([]*batchv1beta1.CronJob, 0, len(cjList.Items))
+	for i := range cjList.Items {
+		var cj batchv1beta1.CronJob
+		if err := fromUnstructured(cjList.Items[i].Object, &cj); err != nil {
+			return nil, err
+		}
+		cjs = append(cjs, &cj)
+	}
+
+	return cjs, nil
+}
+
+func (c *client) ListNamespaces(ctx context.Context) ([]*corev1.Namespace, error) {
+	nsList, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nss := make([]*corev1.Namespace, 0, len(nsList.Items))
+	for i := range nsList.Items {
+		nss = append(nss, &nsList.Items[i])
+	}
+
+	return nss, nil
+}
+
 func (c *client) ListServiceAccounts(ctx context.Context, namespace string) ([]*corev1.ServiceAccount, error) {
 	saList, err := c.clientset.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -79,6 +200,67 @@ func (c *client) ListServiceAccounts(ctx context.Context, namespace string) ([]*
 	return sas, nil
 }
 
+func (c *client) ListSecrets(ctx context.Context, namespace string) ([]*corev1.Secret, error) {
+	secretList, err := c.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]*corev1.Secret, 0, len(secretList.Items))
+	for i := range secretList.Items {
+		secrets = append(secrets, &secretList.Items[i])
+	}
+
+	return secrets, nil
+}
+
+// ListIngresses lists networking.k8s.io/v1 Ingresses, or networking.k8s.io/v1beta1 or extensions/v1beta1 ones
+// on clusters which don't serve newer versions.
+// It returns nil without error if none of them is served.
+func (c *client) ListIngresses(ctx context.Context, namespace string) ([]*Ingress, error) {
+	mapping, err := c.mapper.RESTMapping(schema.GroupKind{Group: GroupNetworking, Kind: KindIngress}, "v1", "v1beta1")
+	if apimeta.IsNoMatchError(err) {
+		mapping, err = c.mapper.RESTMapping(schema.GroupKind{Group: GroupExtensions, Kind: KindIngress}, "v1beta1")
+	}
+	switch {
+	case err == nil:
+	case apimeta.IsNoMatchError(err):
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	ingList, err := c.dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ings := make([]*Ingress, 0, len(ingList.Items))
+	for i := range ingList.Items {
+		var ing Ingress
+		if err := fromUnstructured(ingList.Items[i].Object, &ing); err != nil {
+			return nil, err
+		}
+		ings = append(ings, &ing)
+	}
+
+	return ings, nil
+}
+
+func (c *client) ListPersistentVolumes(ctx context.Context) ([]*corev1.PersistentVolume, error) {
+	pvList, err := c.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pvs := make([]*corev1.PersistentVolume, 0, len(pvList.Items))
+	for i := range pvList.Items {
+		pvs = append(pvs, &pvList.Items[i])
+	}
+
+	return pvs, nil
+}
+
 func (c *client) ListPersistentVolumeClaims(ctx context.Context, namespace string) ([]*corev1.PersistentVolumeClaim, error) {
 	pvcList, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -93,16 +275,134 @@ func (c *client) ListPersistentVolumeClaims(ctx context.Context, namespace strin
 	return pvcs, nil
 }
 
+func (c *client) ListStorageClasses(ctx context.Context) ([]*storagev1.StorageClass, error) {
+	scList, err := c.clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	scs := make([]*storagev1.StorageClass, 0, len(scList.Items))
+	for i := range scList.Items {
+		scs = append(scs, &scList.Items[i])
+	}
+
+	return scs, nil
+}
+
+// ListEndpointSlices lists discovery.k8s.io/v1 EndpointSlices, or discovery.k8s.io/v1beta1 ones if v1 is not served.
+// It returns nil without error if neither of them is served.
+func (c *client) ListEndpointSlices(ctx context.Context, namespace string) ([]*EndpointSlice, error) {
+	mapping, err := c.mapper.RESTMapping(schema.GroupKind{Group: GroupDiscovery, Kind: KindEndpointSlice}, "v1", "v1beta1")
+	switch {
+	case err == nil:
+	case apimeta.IsNoMatchError(err):
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	sliceList, err := c.dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	slices := make([]*EndpointSlice, 0, len(sliceList.Items))
+	for i := range sliceList.Items {
+		var slice EndpointSlice
+		if err := fromUnstructured(sliceList.Items[i].Object, &slice); err != nil {
+			return nil, err
+		}
+		slices = append(slices, &slice)
+	}
+
+	return slices, nil
+}
+
+func (c *client) ListRoles(ctx context.Context, namespace string) ([]*rbacv1.Role, error) {
+	roleList, err := c.clientset.RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]*rbacv1.Role, 0, len(roleList.Items))
+	for i := range roleList.Items {
+		roles = append(roles, &roleList.Items[i])
+	}
+
+	return roles, nil
+}
+
+func (c *client) ListClusterRoles(ctx context.Context) ([]*rbacv1.ClusterRole, error) {
+	crList, err := c.clientset.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	crs := make([]*rbacv1.ClusterRole, 0, len(crList.Items))
+	for i := range crList.Items {
+		crs = append(crs, &crList.Items[i])
+	}
+
+	return crs, nil
+}
+
+func (c *client) ListRoleBindings(ctx context.Context, namespace string) ([]*rbacv1.RoleBinding, error) {
+	rbList, err := c.clientset.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	rbs := make([]*rbacv1.RoleBinding, 0, len(rbList.Items))
+	for i := range rbList.Items {
+		rbs = append(rbs, &rbList.Items[i])
+	}
+
+	return rbs, nil
+}
+
+func (c *client) ListClusterRoleBindings(ctx context.Context) ([]*rbacv1.ClusterRoleBinding, error) {
+	crbList, err := c.clientset.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	crbs := make([]*rbacv1.ClusterRoleBinding, 0, len(crbList.Items))
+	for i := range crbList.Items {
+		crbs = append(crbs, &crbList.Items[i])
+	}
+
+	return crbs, nil
+}
+
+// GetUnstructured gets the object identified by the given apiVersion, kind, name and namespace.
+// If the version is no longer served, the object is got in the preferred version of the group and kind.
+// It returns nil without error if either the object or its kind in any version is not found.
 func (c *client) GetUnstructured(ctx context.Context, apiVersion, kind, name, namespace string) (*unstructured.Unstructured, error) {
 	gv, err := schema.ParseGroupVersion(apiVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	gvk := gv.WithKind(kind)
-	gvr, _ := apimeta.UnsafeGuessKindToResource(gvk)
+	gk := gv.WithKind(kind).GroupKind()
+	mapping, err := c.mapper.RESTMapping(gk, gv.Version)
+	if apimeta.IsNoMatchError(err) {
+		// e.g. the version has been removed in favor of a newer version of the group
+		mapping, err = c.mapper.RESTMapping(gk)
+	}
+	switch {
+	case err == nil:
+	case apimeta.IsNoMatchError(err):
+		return nil, nil // e.g. the CRD of the kind has been deleted
+	default:
+		return nil, err
+	}
+
+	var ri dynamic.ResourceInterface = c.dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == apimeta.RESTScopeNameNamespace {
+		ri = c.dynamicClient.Resource(mapping.Resource).Namespace(namespace)
+	}
 
-	u, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	u, err := ri.Get(ctx, name, metav1.GetOptions{})
 	switch {
 	case err == nil:
 		return u, nil
@@ -6,7 +6,13 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -127,6 +133,81 @@ func Test_client_ListServiceAccounts(t *testing.T) {
 	}
 }
 
+func Test_client_ListCronJobs(t *testing.T) {
+	const (
+		fakeNamespace = "fake-ns"
+		fakeCronJob   = "fake-cj"
+	)
+
+	tests := []struct {
+		name    string
+		scheme  *runtime.Scheme
+		objects []runtime.Object
+		want    []*batchv1beta1.CronJob
+		wantErr bool
+	}{
+		{
+			name:   "batch/v1beta1 CronJobs should be listed when batch/v1 is not served",
+			scheme: scheme.Scheme,
+			objects: []runtime.Object{
+				&batchv1beta1.CronJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeCronJob,
+						Namespace: fakeNamespace,
+					},
+					Spec: batchv1beta1.CronJobSpec{
+						Schedule: "* * * * *",
+					},
+				},
+			},
+			want: []*batchv1beta1.CronJob{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "batch/v1beta1",
+						Kind:       KindCronJob,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeCronJob,
+						Namespace: fakeNamespace,
+					},
+					Spec: batchv1beta1.CronJobSpec{
+						Schedule: "* * * * *",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "nothing should be listed when CronJobs are not served",
+			scheme:  runtime.NewScheme(),
+			want:    nil,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &client{
+				dynamicClient: fakedynamic.NewSimpleDynamicClient(scheme.Scheme, tt.objects...),
+				mapper:        testrestmapper.TestOnlyStaticRESTMapper(tt.scheme),
+			}
+
+			got, err := c.ListCronJobs(context.Background(), fakeNamespace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("client.ListCronJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_client_ListPersistentVolumeClaims(t *testing.T) {
 	const (
 		fakeNamespace             = "fake-ns"
@@ -183,6 +264,301 @@ func Test_client_ListPersistentVolumeClaims(t *testing.T) {
 	}
 }
 
+func Test_client_ListRoles(t *testing.T) {
+	const (
+		fakeNamespace = "fake-ns"
+		fakeRole      = "fake-role"
+	)
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    []*rbacv1.Role
+		wantErr bool
+	}{
+		{
+			name: "expected Roles",
+			objects: []runtime.Object{
+				&rbacv1.Role{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeRole,
+						Namespace: fakeNamespace,
+					},
+				},
+			},
+			want: []*rbacv1.Role{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeRole,
+						Namespace: fakeNamespace,
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &client{
+				clientset: fakeclientset.NewSimpleClientset(tt.objects...),
+			}
+
+			got, err := c.ListRoles(context.Background(), fakeNamespace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("client.ListRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_client_ListRoleBindings(t *testing.T) {
+	const (
+		fakeNamespace   = "fake-ns"
+		fakeRoleBinding = "fake-rb"
+	)
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    []*rbacv1.RoleBinding
+		wantErr bool
+	}{
+		{
+			name: "expected RoleBindings",
+			objects: []runtime.Object{
+				&rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeRoleBinding,
+						Namespace: fakeNamespace,
+					},
+				},
+			},
+			want: []*rbacv1.RoleBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeRoleBinding,
+						Namespace: fakeNamespace,
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &client{
+				clientset: fakeclientset.NewSimpleClientset(tt.objects...),
+			}
+
+			got, err := c.ListRoleBindings(context.Background(), fakeNamespace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("client.ListRoleBindings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_client_ListEndpointSlices(t *testing.T) {
+	const (
+		fakeNamespace     = "fake-ns"
+		fakeEndpointSlice = "fake-svc-abcde"
+		fakeService       = "fake-svc"
+	)
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    []*EndpointSlice
+		wantErr bool
+	}{
+		{
+			name: "discovery.k8s.io/v1beta1 EndpointSlices should be listed when v1 is not served",
+			objects: []runtime.Object{
+				&discoveryv1beta1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeEndpointSlice,
+						Namespace: fakeNamespace,
+						Labels:    map[string]string{LabelServiceName: fakeService},
+					},
+					AddressType: discoveryv1beta1.AddressTypeIPv4,
+					Endpoints: []discoveryv1beta1.Endpoint{
+						{Addresses: []string{"10.0.0.1"}},
+					},
+				},
+			},
+			want: []*EndpointSlice{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "discovery.k8s.io/v1beta1",
+						Kind:       KindEndpointSlice,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeEndpointSlice,
+						Namespace: fakeNamespace,
+						Labels:    map[string]string{LabelServiceName: fakeService},
+					},
+					Endpoints: []Endpoint{
+						{Addresses: []string{"10.0.0.1"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &client{
+				dynamicClient: fakedynamic.NewSimpleDynamicClient(scheme.Scheme, tt.objects...),
+				mapper:        testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme),
+			}
+
+			got, err := c.ListEndpointSlices(context.Background(), fakeNamespace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("client.ListEndpointSlices() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_client_ListIngresses(t *testing.T) {
+	const (
+		fakeNamespace = "fake-ns"
+		fakeIngress   = "fake-ing"
+		fakeSecret    = "fake-secret"
+	)
+
+	extensionsScheme := runtime.NewScheme()
+	if err := extensionsv1beta1.AddToScheme(extensionsScheme); err != nil {
+		t.Fatalf("failed to construct scheme: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		scheme  *runtime.Scheme
+		objects []runtime.Object
+		want    []*Ingress
+		wantErr bool
+	}{
+		{
+			name:   "networking.k8s.io/v1 Ingresses should be listed",
+			scheme: scheme.Scheme,
+			objects: []runtime.Object{
+				&networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeIngress,
+						Namespace: fakeNamespace,
+					},
+					Spec: networkingv1.IngressSpec{
+						TLS: []networkingv1.IngressTLS{{SecretName: fakeSecret}},
+					},
+				},
+			},
+			want: []*Ingress{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "networking.k8s.io/v1",
+						Kind:       KindIngress,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeIngress,
+						Namespace: fakeNamespace,
+					},
+					Spec: IngressSpec{
+						TLS: []IngressTLS{{SecretName: fakeSecret}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "extensions/v1beta1 Ingresses should be listed when networking.k8s.io is not served",
+			scheme: extensionsScheme,
+			objects: []runtime.Object{
+				&extensionsv1beta1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeIngress,
+						Namespace: fakeNamespace,
+					},
+					Spec: extensionsv1beta1.IngressSpec{
+						TLS: []extensionsv1beta1.IngressTLS{{SecretName: fakeSecret}},
+					},
+				},
+			},
+			want: []*Ingress{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "extensions/v1beta1",
+						Kind:       KindIngress,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeIngress,
+						Namespace: fakeNamespace,
+					},
+					Spec: IngressSpec{
+						TLS: []IngressTLS{{SecretName: fakeSecret}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "nothing should be listed when Ingresses are not served",
+			scheme:  runtime.NewScheme(),
+			want:    nil,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &client{
+				dynamicClient: fakedynamic.NewSimpleDynamicClient(scheme.Scheme, tt.objects...),
+				mapper:        testrestmapper.TestOnlyStaticRESTMapper(tt.scheme),
+			}
+
+			got, err := c.ListIngresses(context.Background(), fakeNamespace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("client.ListIngresses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_client_GetUnstructured(t *testing.T) {
 	const (
 		fakeAPIVersion = "apps/v1"
@@ -198,6 +574,31 @@ func Test_client_GetUnstructured(t *testing.T) {
 		namespace  string
 	}
 
+	fakeDeployment := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": fakeAPIVersion,
+			"kind":       fakeKind,
+			"metadata": map[string]interface{}{
+				"creationTimestamp": nil,
+				"name":              fakeName,
+				"namespace":         fakeNamespace,
+			},
+			"spec": map[string]interface{}{
+				"selector": nil,
+				"strategy": map[string]interface{}{},
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"creationTimestamp": nil,
+					},
+					"spec": map[string]interface{}{
+						"containers": nil,
+					},
+				},
+			},
+			"status": map[string]interface{}{},
+		},
+	}
+
 	tests := []struct {
 		name        string
 		args        args
@@ -221,32 +622,52 @@ func Test_client_GetUnstructured(t *testing.T) {
 					},
 				},
 			},
-			want: &unstructured.Unstructured{
-				Object: map[string]interface{}{
-					"apiVersion": fakeAPIVersion,
-					"kind":       fakeKind,
-					"metadata": map[string]interface{}{
-						"creationTimestamp": nil,
-						"name":              fakeName,
-						"namespace":         fakeNamespace,
+			want:    fakeDeployment,
+			wantErr: false,
+		},
+		{
+			name: "object should be got in the preferred version when the version is not served",
+			args: args{
+				apiVersion: "apps/v1beta9",
+				kind:       fakeKind,
+				name:       fakeName,
+				namespace:  fakeNamespace,
+			},
+			fakeObjects: []runtime.Object{
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fakeName,
+						Namespace: fakeNamespace,
 					},
-					"spec": map[string]interface{}{
-						"selector": nil,
-						"strategy": map[string]interface{}{},
-						"template": map[string]interface{}{
-							"metadata": map[string]interface{}{
-								"creationTimestamp": nil,
-							},
-							"spec": map[string]interface{}{
-								"containers": nil,
-							},
-						},
-					},
-					"status": map[string]interface{}{},
 				},
 			},
+			want:    fakeDeployment,
 			wantErr: false,
 		},
+		{
+			name: "nil should be returned when the object is not found",
+			args: args{
+				apiVersion: fakeAPIVersion,
+				kind:       fakeKind,
+				name:       fakeName,
+				namespace:  fakeNamespace,
+			},
+			fakeObjects: []runtime.Object{},
+			want:        nil,
+			wantErr:     false,
+		},
+		{
+			name: "nil should be returned when the kind is not found",
+			args: args{
+				apiVersion: "example.com/v1",
+				kind:       "Rollout",
+				name:       fakeName,
+				namespace:  fakeNamespace,
+			},
+			fakeObjects: []runtime.Object{},
+			want:        nil,
+			wantErr:     false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -257,6 +678,7 @@ func Test_client_GetUnstructured(t *testing.T) {
 
 			c := &client{
 				dynamicClient: fakedynamic.NewSimpleDynamicClient(scheme.Scheme, tt.fakeObjects...),
+				mapper:        testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme),
 			}
 
 			got, err := c.GetUnstructured(context.Background(), tt.args.apiVersion, tt.args.kind, tt.args.name, tt.args.namespace)
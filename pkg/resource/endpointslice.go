@@ -0,0 +1,47 @@
+package resource
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GroupDiscovery is the API group of EndpointSlice.
+	GroupDiscovery = "discovery.k8s.io"
+
+	// LabelServiceName is the label of an EndpointSlice indicating the name of the Service it belongs to.
+	LabelServiceName = "kubernetes.io/service-name"
+	// AnnotationLastChangeTriggerTime is the annotation of an EndpointSlice indicating the time of the last change
+	// of the Service or its Pods which triggered the update of the EndpointSlice.
+	AnnotationLastChangeTriggerTime = "endpoints.kubernetes.io/last-change-trigger-time"
+)
+
+// EndpointSlice holds the fields of an EndpointSlice common to discovery.k8s.io/v1 and discovery.k8s.io/v1beta1.
+type EndpointSlice struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// Endpoint is an endpoint of an EndpointSlice.
+type Endpoint struct {
+	Addresses []string `json:"addresses"`
+}
+
+// ServiceName returns the name of the Service the EndpointSlice belongs to.
+func (s *EndpointSlice) ServiceName() string {
+	return s.Labels[LabelServiceName]
+}
+
+// LastChangeTime returns the time when the EndpointSlice last changed.
+// It falls back to the creation time if the time of the last change is unknown.
+func (s *EndpointSlice) LastChangeTime() time.Time {
+	if v, ok := s.Annotations[AnnotationLastChangeTriggerTime]; ok {
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t
+		}
+	}
+	return s.CreationTimestamp.Time
+}
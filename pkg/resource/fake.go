@@ -5,7 +5,11 @@ import (
 	"sync"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,10 +17,26 @@ import (
 
 type FakeClient struct {
 	fakeObjects                map[fakeObjectKey]runtime.Object
+	fakeNamespaces             []*corev1.Namespace
 	fakePods                   []*corev1.Pod
 	fakeReplicaSets            []*appsv1.ReplicaSet
+	fakeDeployments            []*appsv1.Deployment
+	fakeStatefulSets           []*appsv1.StatefulSet
+	fakeDaemonSets             []*appsv1.DaemonSet
+	fakeJobs                   []*batchv1.Job
+	fakeCronJobs               []*batchv1beta1.CronJob
 	fakeServiceAccounts        []*corev1.ServiceAccount
+	fakeSecrets                []*corev1.Secret
+	fakeIngresses              []*Ingress
+	fakePersistentVolumes      []*corev1.PersistentVolume
 	fakePersistentVolumeClaims []*corev1.PersistentVolumeClaim
+	fakeStorageClasses         []*storagev1.StorageClass
+	fakeEndpointSlices         []*EndpointSlice
+	fakeRoles                  []*rbacv1.Role
+	fakeClusterRoles           []*rbacv1.ClusterRole
+	fakeRoleBindings           []*rbacv1.RoleBinding
+	fakeClusterRoleBindings    []*rbacv1.ClusterRoleBinding
+	fakeErrors                 map[string]error // key=Kind
 
 	mu sync.RWMutex
 }
@@ -32,10 +52,25 @@ func NewFakeClient(objects ...runtime.Object) (*FakeClient, error) {
 	fakeObjects := make(map[fakeObjectKey]runtime.Object)
 
 	var (
+		fakeNamespaces             []*corev1.Namespace
 		fakePods                   []*corev1.Pod
 		fakeReplicaSets            []*appsv1.ReplicaSet
+		fakeDeployments            []*appsv1.Deployment
+		fakeStatefulSets           []*appsv1.StatefulSet
+		fakeDaemonSets             []*appsv1.DaemonSet
+		fakeJobs                   []*batchv1.Job
+		fakeCronJobs               []*batchv1beta1.CronJob
 		fakeServiceAccounts        []*corev1.ServiceAccount
+		fakeSecrets                []*corev1.Secret
+		fakeIngresses              []*Ingress
+		fakePersistentVolumes      []*corev1.PersistentVolume
 		fakePersistentVolumeClaims []*corev1.PersistentVolumeClaim
+		fakeStorageClasses         []*storagev1.StorageClass
+		fakeEndpointSlices         []*EndpointSlice
+		fakeRoles                  []*rbacv1.Role
+		fakeClusterRoles           []*rbacv1.ClusterRole
+		fakeRoleBindings           []*rbacv1.RoleBinding
+		fakeClusterRoleBindings    []*rbacv1.ClusterRoleBinding
 	)
 
 	accessor := apimeta.NewAccessor()
@@ -47,14 +82,62 @@ func NewFakeClient(objects ...runtime.Object) (*FakeClient, error) {
 		}
 
 		switch kind {
+		case KindNamespace:
+			fakeNamespaces = append(fakeNamespaces, obj.(*corev1.Namespace))
 		case KindPod:
 			fakePods = append(fakePods, obj.(*corev1.Pod))
 		case KindReplicaSet:
 			fakeReplicaSets = append(fakeReplicaSets, obj.(*appsv1.ReplicaSet))
+		case KindDeployment:
+			fakeDeployments = append(fakeDeployments, obj.(*appsv1.Deployment))
+		case KindStatefulSet:
+			fakeStatefulSets = append(fakeStatefulSets, obj.(*appsv1.StatefulSet))
+		case KindDaemonSet:
+			fakeDaemonSets = append(fakeDaemonSets, obj.(*appsv1.DaemonSet))
+		case KindJob:
+			fakeJobs = append(fakeJobs, obj.(*batchv1.Job))
+		case KindCronJob:
+			fakeCronJobs = append(fakeCronJobs, obj.(*batchv1beta1.CronJob))
 		case KindServiceAccount:
 			fakeServiceAccounts = append(fakeServiceAccounts, obj.(*corev1.ServiceAccount))
+		case KindSecret:
+			fakeSecrets = append(fakeSecrets, obj.(*corev1.Secret))
+		case KindIngress:
+			// Ingresses of any API version are accepted.
+			u, err := toUnstructured(obj)
+			if err != nil {
+				return nil, err
+			}
+			var ing Ingress
+			if err := fromUnstructured(u, &ing); err != nil {
+				return nil, err
+			}
+			fakeIngresses = append(fakeIngresses, &ing)
+		case KindPersistentVolume:
+			fakePersistentVolumes = append(fakePersistentVolumes, obj.(*corev1.PersistentVolume))
 		case KindPersistentVolumeClaim:
 			fakePersistentVolumeClaims = append(fakePersistentVolumeClaims, obj.(*corev1.PersistentVolumeClaim))
+		case KindStorageClass:
+			fakeStorageClasses = append(fakeStorageClasses, obj.(*storagev1.StorageClass))
+		case KindEndpointSlice:
+			// EndpointSlices of any API version are accepted.
+			u, err := toUnstructured(obj)
+			if err != nil {
+				return nil, err
+			}
+			var slice EndpointSlice
+			if err := fromUnstructured(u, &slice); err != nil {
+				return nil, err
+			}
+			fakeEndpointSlices = append(fakeEndpointSlices, &slice)
+		case KindRole:
+			fakeRoles = append(fakeRoles, obj.(*rbacv1.Role))
+		case KindClusterRole:
+			fakeClusterRoles = append(fakeClusterRoles, obj.(*rbacv1.ClusterRole))
+		case KindRoleBinding:
+			fakeRoleBindings = append(fakeRoleBindings, obj.(*rbacv1.RoleBinding))
+		case KindClusterRoleBinding:
+			fakeClusterRoleBindings = append(fakeClusterRoleBindings, obj.(*rbacv1.ClusterRoleBinding))
 		}
 
 		apiVersion, err := accessor.APIVersion(obj)
@@ -81,43 +164,229 @@ func NewFakeClient(objects ...runtime.Object) (*FakeClient, error) {
 
 	return &FakeClient{
 		fakeObjects:                fakeObjects,
+		fakeNamespaces:             fakeNamespaces,
 		fakePods:                   fakePods,
+		fakeReplicaSets:            fakeReplicaSets,
+		fakeDeployments:            fakeDeployments,
+		fakeStatefulSets:           fakeStatefulSets,
+		fakeDaemonSets:             fakeDaemonSets,
+		fakeJobs:                   fakeJobs,
+		fakeCronJobs:               fakeCronJobs,
 		fakeServiceAccounts:        fakeServiceAccounts,
+		fakeSecrets:                fakeSecrets,
+		fakeIngresses:              fakeIngresses,
+		fakePersistentVolumes:      fakePersistentVolumes,
 		fakePersistentVolumeClaims: fakePersistentVolumeClaims,
+		fakeStorageClasses:         fakeStorageClasses,
+		fakeEndpointSlices:         fakeEndpointSlices,
+		fakeRoles:                  fakeRoles,
+		fakeClusterRoles:           fakeClusterRoles,
+		fakeRoleBindings:           fakeRoleBindings,
+		fakeClusterRoleBindings:    fakeClusterRoleBindings,
+		fakeErrors:                 make(map[string]error),
 	}, nil
 }
 
+// SetError makes listing and getting objects of the kind fail with err, e.g. to simulate a lack of permission.
+func (c *FakeClient) SetError(kind string, err error) {
+	c.mu.Lock()
+	c.fakeErrors[kind] = err
+	c.mu.Unlock()
+}
+
 // Guarantee *FakeClient implements Client.
 var _ Client = (*FakeClient)(nil)
 
 func (c *FakeClient) ListPods(ctx context.Context, namespace string) ([]*corev1.Pod, error) {
 	c.mu.RLock()
-	pods := c.fakePods
+	pods, err := c.fakePods, c.fakeErrors[KindPod]
 	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
 	return pods, nil
 }
 
 func (c *FakeClient) ListReplicaSets(ctx context.Context, namespace string) ([]*appsv1.ReplicaSet, error) {
 	c.mu.RLock()
-	rss := c.fakeReplicaSets
+	rss, err := c.fakeReplicaSets, c.fakeErrors[KindReplicaSet]
 	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
 	return rss, nil
 }
 
+func (c *FakeClient) ListDeployments(ctx context.Context, namespace string) ([]*appsv1.Deployment, error) {
+	c.mu.RLock()
+	deploys, err := c.fakeDeployments, c.fakeErrors[KindDeployment]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return deploys, nil
+}
+
+func (c *FakeClient) ListStatefulSets(ctx context.Context, namespace string) ([]*appsv1.StatefulSet, error) {
+	c.mu.RLock()
+	stss, err := c.fakeStatefulSets, c.fakeErrors[KindStatefulSet]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return stss, nil
+}
+
+func (c *FakeClient) ListDaemonSets(ctx context.Context, namespace string) ([]*appsv1.DaemonSet, error) {
+	c.mu.RLock()
+	dss, err := c.fakeDaemonSets, c.fakeErrors[KindDaemonSet]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return dss, nil
+}
+
+func (c *FakeClient) ListJobs(ctx context.Context, namespace string) ([]*batchv1.Job, error) {
+	c.mu.RLock()
+	jobs, err := c.fakeJobs, c.fakeErrors[KindJob]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (c *FakeClient) ListCronJobs(ctx context.Context, namespace string) ([]*batchv1beta1.CronJob, error) {
+	c.mu.RLock()
+	cjs, err := c.fakeCronJobs, c.fakeErrors[KindCronJob]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return cjs, nil
+}
+
+func (c *FakeClient) ListNamespaces(ctx context.Context) ([]*corev1.Namespace, error) {
+	c.mu.RLock()
+	nss, err := c.fakeNamespaces, c.fakeErrors[KindNamespace]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return nss, nil
+}
+
 func (c *FakeClient) ListServiceAccounts(ctx context.Context, namespace string) ([]*corev1.ServiceAccount, error) {
 	c.mu.RLock()
-	sas := c.fakeServiceAccounts
+	sas, err := c.fakeServiceAccounts, c.fakeErrors[KindServiceAccount]
 	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
 	return sas, nil
 }
 
+func (c *FakeClient) ListSecrets(ctx context.Context, namespace string) ([]*corev1.Secret, error) {
+	c.mu.RLock()
+	secrets, err := c.fakeSecrets, c.fakeErrors[KindSecret]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+func (c *FakeClient) ListIngresses(ctx context.Context, namespace string) ([]*Ingress, error) {
+	c.mu.RLock()
+	ings, err := c.fakeIngresses, c.fakeErrors[KindIngress]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return ings, nil
+}
+
+func (c *FakeClient) ListPersistentVolumes(ctx context.Context) ([]*corev1.PersistentVolume, error) {
+	c.mu.RLock()
+	pvs, err := c.fakePersistentVolumes, c.fakeErrors[KindPersistentVolume]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return pvs, nil
+}
+
 func (c *FakeClient) ListPersistentVolumeClaims(ctx context.Context, namespace string) ([]*corev1.PersistentVolumeClaim, error) {
 	c.mu.RLock()
-	pvcs := c.fakePersistentVolumeClaims
+	pvcs, err := c.fakePersistentVolumeClaims, c.fakeErrors[KindPersistentVolumeClaim]
 	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
 	return pvcs, nil
 }
 
+func (c *FakeClient) ListStorageClasses(ctx context.Context) ([]*storagev1.StorageClass, error) {
+	c.mu.RLock()
+	scs, err := c.fakeStorageClasses, c.fakeErrors[KindStorageClass]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return scs, nil
+}
+
+func (c *FakeClient) ListEndpointSlices(ctx context.Context, namespace string) ([]*EndpointSlice, error) {
+	c.mu.RLock()
+	slices, err := c.fakeEndpointSlices, c.fakeErrors[KindEndpointSlice]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return slices, nil
+}
+
+func (c *FakeClient) ListRoles(ctx context.Context, namespace string) ([]*rbacv1.Role, error) {
+	c.mu.RLock()
+	roles, err := c.fakeRoles, c.fakeErrors[KindRole]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (c *FakeClient) ListClusterRoles(ctx context.Context) ([]*rbacv1.ClusterRole, error) {
+	c.mu.RLock()
+	crs, err := c.fakeClusterRoles, c.fakeErrors[KindClusterRole]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return crs, nil
+}
+
+func (c *FakeClient) ListRoleBindings(ctx context.Context, namespace string) ([]*rbacv1.RoleBinding, error) {
+	c.mu.RLock()
+	rbs, err := c.fakeRoleBindings, c.fakeErrors[KindRoleBinding]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return rbs, nil
+}
+
+func (c *FakeClient) ListClusterRoleBindings(ctx context.Context) ([]*rbacv1.ClusterRoleBinding, error) {
+	c.mu.RLock()
+	crbs, err := c.fakeClusterRoleBindings, c.fakeErrors[KindClusterRoleBinding]
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return crbs, nil
+}
+
 func (c *FakeClient) GetUnstructured(ctx context.Context, apiVersion, kind, name, namespace string) (*unstructured.Unstructured, error) {
 	key := fakeObjectKey{
 		apiVersion: apiVersion,
@@ -128,7 +397,11 @@ func (c *FakeClient) GetUnstructured(ctx context.Context, apiVersion, kind, name
 
 	c.mu.RLock()
 	obj, ok := c.fakeObjects[key]
+	err := c.fakeErrors[kind]
 	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
 	if !ok {
 		return nil, nil
 	}
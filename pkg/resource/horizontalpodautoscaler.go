@@ -0,0 +1,69 @@
+package resource
+
+import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// APIVersionAutoscalingV1 is the API version of HorizontalPodAutoscaler which only supports CPU utilization.
+const APIVersionAutoscalingV1 = "autoscaling/v1"
+
+// ObjectToHorizontalPodAutoscaler converts a HorizontalPodAutoscaler in any API version into autoscaling/v2 form.
+// autoscaling/v2 shares its schema with autoscaling/v2beta2, and autoscaling/v2beta1 differs only in metrics.
+// The CPU utilization target of autoscaling/v1 is converted into a resource metric.
+func ObjectToHorizontalPodAutoscaler(obj runtime.Object) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj.GetObjectKind().GroupVersionKind().GroupVersion().String() == APIVersionAutoscalingV1 {
+		var v1 autoscalingv1.HorizontalPodAutoscaler
+		if err := fromUnstructured(u, &v1); err != nil {
+			return nil, err
+		}
+		return convertHorizontalPodAutoscalerV1(&v1), nil
+	}
+
+	var hpa autoscalingv2beta2.HorizontalPodAutoscaler
+	if err := fromUnstructured(u, &hpa); err != nil {
+		return nil, err
+	}
+
+	return &hpa, nil
+}
+
+func convertHorizontalPodAutoscalerV1(v1 *autoscalingv1.HorizontalPodAutoscaler) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		TypeMeta:   v1.TypeMeta,
+		ObjectMeta: v1.ObjectMeta,
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: v1.Spec.ScaleTargetRef.APIVersion,
+				Kind:       v1.Spec.ScaleTargetRef.Kind,
+				Name:       v1.Spec.ScaleTargetRef.Name,
+			},
+			MinReplicas: v1.Spec.MinReplicas,
+			MaxReplicas: v1.Spec.MaxReplicas,
+		},
+	}
+
+	if v1.Spec.TargetCPUUtilizationPercentage != nil {
+		hpa.Spec.Metrics = []autoscalingv2beta2.MetricSpec{
+			{
+				Type: autoscalingv2beta2.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2beta2.MetricTarget{
+						Type:               autoscalingv2beta2.UtilizationMetricType,
+						AverageUtilization: v1.Spec.TargetCPUUtilizationPercentage,
+					},
+				},
+			},
+		}
+	}
+
+	return hpa
+}
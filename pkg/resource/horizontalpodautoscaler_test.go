@@ -0,0 +1,138 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestObjectToHorizontalPodAutoscaler(t *testing.T) {
+	const (
+		fakeHorizontalPodAutoscaler = "fake-hpa"
+		fakeDeployment              = "fake-deploy"
+	)
+
+	var (
+		fakeMinReplicas      int32 = 1
+		fakeUtilization      int32 = 80
+		fakeScaleTargetRefV1       = autoscalingv1.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       fakeDeployment,
+		}
+		fakeScaleTargetRefV2 = autoscalingv2beta2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       fakeDeployment,
+		}
+	)
+
+	tests := []struct {
+		name    string
+		obj     runtime.Object
+		want    *autoscalingv2beta2.HorizontalPodAutoscaler
+		wantErr bool
+	}{
+		{
+			name: "autoscaling/v1 HorizontalPodAutoscaler should be converted with CPU resource metric",
+			obj: &autoscalingv1.HorizontalPodAutoscaler{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: APIVersionAutoscalingV1,
+					Kind:       KindHorizontalPodAutoscaler,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fakeHorizontalPodAutoscaler,
+				},
+				Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef:                 fakeScaleTargetRefV1,
+					MinReplicas:                    &fakeMinReplicas,
+					MaxReplicas:                    3,
+					TargetCPUUtilizationPercentage: &fakeUtilization,
+				},
+			},
+			want: &autoscalingv2beta2.HorizontalPodAutoscaler{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: APIVersionAutoscalingV1,
+					Kind:       KindHorizontalPodAutoscaler,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fakeHorizontalPodAutoscaler,
+				},
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: fakeScaleTargetRefV2,
+					MinReplicas:    &fakeMinReplicas,
+					MaxReplicas:    3,
+					Metrics: []autoscalingv2beta2.MetricSpec{
+						{
+							Type: autoscalingv2beta2.ResourceMetricSourceType,
+							Resource: &autoscalingv2beta2.ResourceMetricSource{
+								Name: corev1.ResourceCPU,
+								Target: autoscalingv2beta2.MetricTarget{
+									Type:               autoscalingv2beta2.UtilizationMetricType,
+									AverageUtilization: &fakeUtilization,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "autoscaling/v2 HorizontalPodAutoscaler should be decoded as is",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "autoscaling/v2",
+					"kind":       KindHorizontalPodAutoscaler,
+					"metadata": map[string]interface{}{
+						"name": fakeHorizontalPodAutoscaler,
+					},
+					"spec": map[string]interface{}{
+						"scaleTargetRef": map[string]interface{}{
+							"apiVersion": "apps/v1",
+							"kind":       "Deployment",
+							"name":       fakeDeployment,
+						},
+						"maxReplicas": int64(3),
+					},
+				},
+			},
+			want: &autoscalingv2beta2.HorizontalPodAutoscaler{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "autoscaling/v2",
+					Kind:       KindHorizontalPodAutoscaler,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fakeHorizontalPodAutoscaler,
+				},
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: fakeScaleTargetRefV2,
+					MaxReplicas:    3,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ObjectToHorizontalPodAutoscaler(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ObjectToHorizontalPodAutoscaler() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package resource
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GroupNetworking is the API group of Ingress since Kubernetes 1.14.
+	GroupNetworking = "networking.k8s.io"
+	// GroupExtensions is the API group of Ingress before Kubernetes 1.14.
+	GroupExtensions = "extensions"
+)
+
+// Ingress holds the fields of an Ingress common to networking.k8s.io/v1, networking.k8s.io/v1beta1 and extensions/v1beta1.
+type Ingress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IngressSpec `json:"spec,omitempty"`
+}
+
+// IngressSpec holds the fields of an Ingress spec common to all the API versions.
+type IngressSpec struct {
+	TLS []IngressTLS `json:"tls,omitempty"`
+}
+
+// IngressTLS is the TLS configuration of an Ingress.
+type IngressTLS struct {
+	SecretName string `json:"secretName,omitempty"`
+}
@@ -0,0 +1,84 @@
+package resource
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// APIVersionPolicyV1 is the GA API version of PodDisruptionBudget.
+	APIVersionPolicyV1 = "policy/v1"
+	// APIVersionPolicyV1beta1 is the deprecated API version of PodDisruptionBudget.
+	APIVersionPolicyV1beta1 = "policy/v1beta1"
+)
+
+// UnhealthyPodEvictionPolicyType defines the criteria for when unhealthy Pods should be considered for eviction.
+type UnhealthyPodEvictionPolicyType string
+
+const (
+	// IfHealthyBudget policy means that running Pods which are not yet healthy can be evicted
+	// only if the guarded application is not disrupted.
+	IfHealthyBudget UnhealthyPodEvictionPolicyType = "IfHealthyBudget"
+	// AlwaysAllow policy means that all running Pods which are not yet healthy can be evicted.
+	AlwaysAllow UnhealthyPodEvictionPolicyType = "AlwaysAllow"
+)
+
+// PodDisruptionBudget holds the fields of a PodDisruptionBudget common to policy/v1 and policy/v1beta1.
+type PodDisruptionBudget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PodDisruptionBudgetSpec `json:"spec,omitempty"`
+}
+
+// PodDisruptionBudgetSpec is the spec of PodDisruptionBudget.
+type PodDisruptionBudgetSpec struct {
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// UnhealthyPodEvictionPolicy is only available in policy/v1.
+	UnhealthyPodEvictionPolicy *UnhealthyPodEvictionPolicyType `json:"unhealthyPodEvictionPolicy,omitempty"`
+}
+
+// UnhealthyPodEvictionPolicy returns the policy for evicting unhealthy Pods, which is IfHealthyBudget if unset in policy/v1.
+// It returns an empty string in policy/v1beta1, which doesn't support the policy.
+func (pdb *PodDisruptionBudget) UnhealthyPodEvictionPolicy() UnhealthyPodEvictionPolicyType {
+	if pdb.APIVersion == APIVersionPolicyV1beta1 {
+		return ""
+	}
+	if pdb.Spec.UnhealthyPodEvictionPolicy == nil {
+		return IfHealthyBudget
+	}
+	return *pdb.Spec.UnhealthyPodEvictionPolicy
+}
+
+// LabelSelector returns the selector for the Pods targeted by the PodDisruptionBudget.
+// A nil selector selects no Pods. An empty selector selects all the Pods in the namespace in policy/v1,
+// while it selects no Pods in policy/v1beta1.
+func (pdb *PodDisruptionBudget) LabelSelector() (labels.Selector, error) {
+	s := pdb.Spec.Selector
+	if s == nil {
+		return labels.Nothing(), nil
+	}
+
+	if len(s.MatchLabels)+len(s.MatchExpressions) == 0 && pdb.APIVersion == APIVersionPolicyV1beta1 {
+		return labels.Nothing(), nil
+	}
+
+	return metav1.LabelSelectorAsSelector(s)
+}
+
+// ObjectToPodDisruptionBudget converts a policy/v1 or policy/v1beta1 PodDisruptionBudget into PodDisruptionBudget.
+func ObjectToPodDisruptionBudget(obj runtime.Object) (*PodDisruptionBudget, error) {
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var pdb PodDisruptionBudget
+	if err := fromUnstructured(u, &pdb); err != nil {
+		return nil, err
+	}
+
+	return &pdb, nil
+}
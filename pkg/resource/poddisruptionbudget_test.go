@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"testing"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPodDisruptionBudget_UnhealthyPodEvictionPolicy(t *testing.T) {
+	const fakePodDisruptionBudget = "fake-pdb"
+
+	newV1 := func(spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": APIVersionPolicyV1,
+				"kind":       KindPodDisruptionBudget,
+				"metadata": map[string]interface{}{
+					"name": fakePodDisruptionBudget,
+				},
+				"spec": spec,
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		obj  runtime.Object
+		want UnhealthyPodEvictionPolicyType
+	}{
+		{
+			name: "policy/v1 PodDisruptionBudget should be decoded with the policy",
+			obj: newV1(map[string]interface{}{
+				"unhealthyPodEvictionPolicy": string(AlwaysAllow),
+			}),
+			want: AlwaysAllow,
+		},
+		{
+			name: "policy/v1 PodDisruptionBudget should default to IfHealthyBudget",
+			obj:  newV1(map[string]interface{}{}),
+			want: IfHealthyBudget,
+		},
+		{
+			name: "policy/v1beta1 PodDisruptionBudget should have no policy",
+			obj: &policyv1beta1.PodDisruptionBudget{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: APIVersionPolicyV1beta1,
+					Kind:       KindPodDisruptionBudget,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fakePodDisruptionBudget,
+				},
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pdb, err := ObjectToPodDisruptionBudget(tt.obj)
+			if err != nil {
+				t.Errorf("ObjectToPodDisruptionBudget() error = %v", err)
+				return
+			}
+
+			if got := pdb.UnhealthyPodEvictionPolicy(); got != tt.want {
+				t.Errorf("PodDisruptionBudget.UnhealthyPodEvictionPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,24 +2,36 @@ package resource
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
+	KindNamespace               = "Namespace"
 	KindPod                     = "Pod"
 	KindReplicaSet              = "ReplicaSet"
+	KindDeployment              = "Deployment"
+	KindStatefulSet             = "StatefulSet"
+	KindDaemonSet               = "DaemonSet"
+	KindCronJob                 = "CronJob"
 	KindConfigMap               = "ConfigMap"
 	KindSecret                  = "Secret"
 	KindServiceAccount          = "ServiceAccount"
 	KindPersistentVolume        = "PersistentVolume"
 	KindPersistentVolumeClaim   = "PersistentVolumeClaim"
+	KindStorageClass            = "StorageClass"
+	KindIngress                 = "Ingress"
 	KindJob                     = "Job"
 	KindPodDisruptionBudget     = "PodDisruptionBudget"
 	KindHorizontalPodAutoscaler = "HorizontalPodAutoscaler"
+	KindService                 = "Service"
+	KindEndpointSlice           = "EndpointSlice"
+	KindRole                    = "Role"
+	KindClusterRole             = "ClusterRole"
+	KindRoleBinding             = "RoleBinding"
+	KindClusterRoleBinding      = "ClusterRoleBinding"
 )
 
 var unstructuredConverter = runtime.DefaultUnstructuredConverter
@@ -66,46 +78,88 @@ func ObjectToPersistentVolume(obj runtime.Object) (*corev1.PersistentVolume, err
 	return &volume, nil
 }
 
-func ObjectToJob(obj runtime.Object) (*batchv1.Job, error) {
+func ObjectToService(obj runtime.Object) (*corev1.Service, error) {
 	u, err := toUnstructured(obj)
 	if err != nil {
 		return nil, err
 	}
 
-	var job batchv1.Job
-	if err := fromUnstructured(u, &job); err != nil {
+	var svc corev1.Service
+	if err := fromUnstructured(u, &svc); err != nil {
 		return nil, err
 	}
 
-	return &job, nil
+	return &svc, nil
+}
+
+func ObjectToServiceAccount(obj runtime.Object) (*corev1.ServiceAccount, error) {
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var sa corev1.ServiceAccount
+	if err := fromUnstructured(u, &sa); err != nil {
+		return nil, err
+	}
+
+	return &sa, nil
+}
+
+func ObjectToClusterRole(obj runtime.Object) (*rbacv1.ClusterRole, error) {
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var cr rbacv1.ClusterRole
+	if err := fromUnstructured(u, &cr); err != nil {
+		return nil, err
+	}
+
+	return &cr, nil
 }
 
-func ObjectToPodDisruptionBudget(obj runtime.Object) (*policyv1beta1.PodDisruptionBudget, error) {
+func ObjectToRoleBinding(obj runtime.Object) (*rbacv1.RoleBinding, error) {
 	u, err := toUnstructured(obj)
 	if err != nil {
 		return nil, err
 	}
 
-	var pdb policyv1beta1.PodDisruptionBudget
-	if err := fromUnstructured(u, &pdb); err != nil {
+	var rb rbacv1.RoleBinding
+	if err := fromUnstructured(u, &rb); err != nil {
 		return nil, err
 	}
 
-	return &pdb, nil
+	return &rb, nil
 }
 
-func ObjectToHorizontalPodAutoscaler(obj runtime.Object) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+func ObjectToClusterRoleBinding(obj runtime.Object) (*rbacv1.ClusterRoleBinding, error) {
 	u, err := toUnstructured(obj)
 	if err != nil {
 		return nil, err
 	}
 
-	var hpa autoscalingv1.HorizontalPodAutoscaler
-	if err := fromUnstructured(u, &hpa); err != nil {
+	var crb rbacv1.ClusterRoleBinding
+	if err := fromUnstructured(u, &crb); err != nil {
 		return nil, err
 	}
 
-	return &hpa, nil
+	return &crb, nil
+}
+
+func ObjectToJob(obj runtime.Object) (*batchv1.Job, error) {
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var job batchv1.Job
+	if err := fromUnstructured(u, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
 }
 
 func toUnstructured(obj runtime.Object) (map[string]interface{}, error) {